@@ -0,0 +1,104 @@
+package validator
+
+/*
+This function makes every rule of every field run and report its error, for the rest of the validator
+
+By default the first error of a field ends its validation. With AllErrors() the rules of a field keep
+running after a failure, so that e.g. every unmet password requirement is reported at once.
+A missing required field is still reported once, by a single required error.
+
+returns: *Validator
+
+Example:
+
+	errs := NewValidator("password", data.Password).AllErrors().
+	    Min(8).HasSpecialChar().Match(digitRegex).
+	    GetError()
+	// up to three errors for "password"
+*/
+func (v *Validator) AllErrors() *Validator {
+	v.allErrors = true
+	return v
+}
+
+/*
+This function stops the whole validator once it collected n errors, across all fields
+
+- n: the maximum number of errors, 0 for no limit
+
+returns: *Validator
+
+Rules of the fields declared after the limit is reached are skipped, and so are pending CustomCtx() rules.
+*/
+func (v *Validator) MaxErrors(n int) *Validator {
+	v.maxErrors = n
+	return v
+}
+
+/*
+This function stops the whole validator at its first error, i.e. MaxErrors(1)
+
+returns: *Validator
+*/
+func (v *Validator) FailFast() *Validator {
+	return v.MaxErrors(1)
+}
+
+// limitReached reports whether the validator collected MaxErrors() errors
+func (v *Validator) limitReached() bool {
+	return v.maxErrors > 0 && len(v.errors) >= v.maxErrors
+}
+
+// fieldFailed reports whether the rules of the current field must be skipped
+func (v *Validator) fieldFailed() bool {
+	return v.foundErr && !v.allErrors || v.limitReached()
+}
+
+/*
+This function makes every rule of every field run and report its error, see Validator.AllErrors()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) AllErrors() *Schema {
+	schema := *s
+	schema.allErrors = true
+	return &schema
+}
+
+/*
+This function stops the validation once n errors were collected, see Validator.MaxErrors()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) MaxErrors(n int) *Schema {
+	schema := *s
+	schema.maxErrors = n
+	return &schema
+}
+
+/*
+This function stops the validation at the first error, see Validator.FailFast()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) FailFast() *Schema {
+	return s.MaxErrors(1)
+}
+
+/*
+This function makes every rule of this field run and report its error, see Validator.AllErrors()
+
+returns: *SchemaField
+
+Example:
+
+	schema := validator.NewSchema(
+	    validator.Field("email").Email(),
+	    validator.Field("password").AllErrors().Min(8).HasSpecialChar(),
+	)
+*/
+func (f *SchemaField) AllErrors() *SchemaField {
+	field := *f
+	field.allErrors = true
+	return &field
+}
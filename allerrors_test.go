@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+var digitRegex = regexp.MustCompile(`[0-9]`)
+
+func errorCodes(errs ValidationErrors) []string {
+	codes := make([]string, len(errs))
+	for i, err := range errs {
+		codes[i] = err.Field + ":" + err.Code
+	}
+	return codes
+}
+
+func TestAllErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		password interface{}
+		want     []string
+	}{
+		{"every requirement", "abc", []string{"password:min", "password:has_special", "password:match"}},
+		{"some requirements", "abcdefgh!", []string{"password:match"}},
+		{"valid", "abcdefg1!", []string{}},
+		{"missing", nil, []string{"password:required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewValidator("password", tt.password).AllErrors().
+				Min(8).HasSpecialChar().Match(digitRegex).
+				GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetError() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the default stays first error per field
+	errs := NewValidator("password", "abc").Min(8).HasSpecialChar().Match(digitRegex).GetError()
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"password:min"}) {
+		t.Errorf("without AllErrors(): GetError() = %v", got)
+	}
+}
+
+func TestAllErrorsAcrossFields(t *testing.T) {
+	v := NewValidator("email", "ann").AllErrors().Email().Min(5)
+	v.NextField("username", "a!").Min(3).Alpha()
+	v.NextField("nickname", "").NotRequired().ZeroIsEmpty().Min(3).Alpha()
+
+	want := []string{"email:email", "email:min", "username:min", "username:alpha"}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetError() = %v; want %v", got, want)
+	}
+}
+
+func TestAllErrorsCustomCtx(t *testing.T) {
+	fail := func(message string) func(context.Context, interface{}) error {
+		return func(context.Context, interface{}) error { return errors.New(message) }
+	}
+	v := NewValidator("username", "ab").AllErrors().Min(3).
+		CustomCtx(fail("is already taken")).
+		CustomCtx(fail("is reserved"))
+	if err := v.ValidateCtx(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"username:min", "username:custom", "username:custom"}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetError() = %v; want %v", got, want)
+	}
+}
+
+func TestMaxErrors(t *testing.T) {
+	build := func(v *Validator) *Validator {
+		v.NextField("email", "ann").Email()
+		v.NextField("username", "a").Min(3)
+		v.NextField("age", 12).Min(18)
+		return v
+	}
+
+	tests := []struct {
+		name string
+		v    *Validator
+		want []string
+	}{
+		{"no limit", build(NewValidator("name", "").ZeroIsEmpty().Alpha()), []string{"name:required", "email:email", "username:min", "age:min"}},
+		{"max errors", build(NewValidator("name", "Ann").MaxErrors(2).Alpha()), []string{"email:email", "username:min"}},
+		{"fail fast", build(NewValidator("name", "Ann").FailFast().Alpha()), []string{"email:email"}},
+		{"fail fast with all errors", build(NewValidator("name", "4!").FailFast().AllErrors().Alpha().Min(3)), []string{"name:alpha"}},
+		{"limit across all errors", build(NewValidator("name", "4!").MaxErrors(3).AllErrors().Alpha().Min(3)), []string{"name:alpha", "name:min", "email:email"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCodes(tt.v.GetError()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetError() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxErrorsCustomCtx(t *testing.T) {
+	calls := 0
+	check := func(context.Context, interface{}) error {
+		calls++
+		return errors.New("is already taken")
+	}
+	v := NewValidator("email", "ann").FailFast().Email().CustomCtx(check)
+	v.NextField("username", "ann").CustomCtx(check)
+	if err := v.ValidateCtx(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"email:email"}) || calls != 0 {
+		t.Errorf("GetError() = %v after %d checks", got, calls)
+	}
+}
+
+func TestSchemaAllErrors(t *testing.T) {
+	schema := NewSchema(
+		Field("email").Email().Min(5),
+		Field("password").AllErrors().Min(8).HasSpecialChar().Match(digitRegex),
+		Field("items").Each(Elem().Nested(NewSchema(Field("sku").Alpha().Min(3)))),
+	)
+	data := map[string]interface{}{
+		"email":    "ann",
+		"password": "abc",
+		"items":    []interface{}{map[string]interface{}{"sku": "1"}},
+	}
+
+	want := []string{"email:email", "password:min", "password:has_special", "password:match", "items[0].sku:alpha"}
+	if got := errorCodes(schema.Validate(data)); !reflect.DeepEqual(got, want) {
+		t.Errorf("per field: Validate() = %v; want %v", got, want)
+	}
+
+	want = []string{"email:email", "email:min", "password:min", "password:has_special", "password:match", "items[0].sku:alpha", "items[0].sku:min"}
+	if got := errorCodes(schema.AllErrors().Validate(data)); !reflect.DeepEqual(got, want) {
+		t.Errorf("per schema: Validate() = %v; want %v", got, want)
+	}
+
+	if got := errorCodes(schema.FailFast().Validate(data)); !reflect.DeepEqual(got, []string{"email:email"}) {
+		t.Errorf("FailFast(): Validate() = %v", got)
+	}
+	if got := errorCodes(schema.AllErrors().MaxErrors(3).Validate(data)); !reflect.DeepEqual(got, []string{"email:email", "email:min", "password:min"}) {
+		t.Errorf("MaxErrors(3): Validate() = %v", got)
+	}
+}
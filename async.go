@@ -0,0 +1,237 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// CodeCustom is reported by custom rules returning a plain error
+const CodeCustom = "custom"
+
+// CodeUnchecked is reported by GetError() for fields whose CustomCtx() rules never ran
+const CodeUnchecked = "unchecked"
+
+// asyncCheck is a CustomCtx rule waiting for ValidateCtx
+type asyncCheck struct {
+	field ValidationError // Field, Path and Value of the checked field
+	fn    func(ctx context.Context, value interface{}) error
+}
+
+// asyncField groups the checks of a single field, which run in order and stop at the first error
+// unless allErrors is set
+type asyncField struct {
+	checks    []asyncCheck
+	allErrors bool
+}
+
+/*
+This function adds a context-aware rule, e.g. a database or remote lookup
+
+- fn: returns nil when the value is valid. A returned ValidationError keeps its Code and Message,
+any other error is reported with Code "custom" and the error text as Message
+
+returns: *Validator
+
+Note: The rule only runs when ValidateCtx() is called, until then GetError() reports the field with
+the unchecked code. Checks of different fields run concurrently, so fn must be safe for concurrent use.
+
+Example:
+
+	validator := NewValidator("username", data.Username).Min(5).
+	    CustomCtx(func(ctx context.Context, value interface{}) error {
+	        taken, err := users.Exists(ctx, value.(string))
+	        if err != nil {
+	            return err
+	        }
+	        if taken {
+	            return errors.New("is already taken")
+	        }
+	        return nil
+	    })
+
+	if err := validator.ValidateCtx(ctx); err != nil {
+	    // the context was cancelled or its deadline exceeded
+	}
+	errs := validator.GetError()
+*/
+func (v *Validator) CustomCtx(fn func(ctx context.Context, value interface{}) error) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	check := asyncCheck{
+		field: ValidationError{Field: v.fieldName, Path: v.errorPath(), Value: v.data},
+		fn:    fn,
+	}
+	if n := len(v.async); n > 0 && v.async[n-1].checks[0].field.Field == v.fieldName {
+		v.async[n-1].checks = append(v.async[n-1].checks, check)
+	} else {
+		v.async = append(v.async, asyncField{checks: []asyncCheck{check}, allErrors: v.allErrors})
+	}
+	return v
+}
+
+/*
+This function sets how many fields ValidateCtx() checks concurrently
+
+- n: number of workers, defaults to GOMAXPROCS
+
+returns: *Validator
+*/
+func (v *Validator) Workers(n int) *Validator {
+	v.workers = n
+	return v
+}
+
+/*
+This function runs the pending CustomCtx() rules
+
+Checks of independent fields run concurrently on a bounded number of workers, their errors are
+appended to GetError() in the order the fields were declared, whatever order they complete in.
+Fields that already failed a synchronous rule are not checked.
+
+- ctx: cancelling ctx or exceeding its deadline stops the remaining checks
+
+returns: ctx.Err() when the checks were interrupted, nil otherwise
+
+Note: Fields whose checks were interrupted or never started because ctx was done keep being reported
+by GetError() with the unchecked code, so that a validator whose checks did not complete never passes.
+*/
+func (v *Validator) ValidateCtx(ctx context.Context) error {
+	pending := v.async
+	v.async = nil
+
+	// first error per field: a field that failed synchronously is not checked any further
+	failed := make(map[string]bool, len(v.errors))
+	for _, err := range v.errors {
+		failed[err.Field] = true
+	}
+
+	workers := v.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([][]ValidationError, len(pending))
+	// completed fields ran every check they needed, the others were interrupted or never started
+	completed := make([]bool, len(pending))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+launch:
+	for i, field := range pending {
+		if v.limitReached() {
+			break
+		}
+		if failed[field.checks[0].field.Field] && !field.allErrors {
+			completed[i] = true
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+
+		wg.Add(1)
+		go func(i int, field asyncField) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], completed[i] = field.run(ctx)
+		}(i, field)
+	}
+	wg.Wait()
+
+	for i, errs := range results {
+		for _, err := range errs {
+			v.recordError(err)
+		}
+		if !completed[i] && len(errs) == 0 {
+			v.recordError(uncheckedError(pending[i].checks[0].field))
+		}
+	}
+	return ctx.Err()
+}
+
+// uncheckedErrors returns the errors of the fields whose CustomCtx() rules are still pending,
+// so that a validator whose ValidateCtx() was never called does not pass silently
+func (v *Validator) uncheckedErrors() []ValidationError {
+	failed := make(map[string]bool, len(v.errors))
+	for _, err := range v.errors {
+		failed[err.Field] = true
+	}
+
+	var errs []ValidationError
+	for _, field := range v.async {
+		check := field.checks[0].field
+		if failed[check.Field] && !field.allErrors {
+			continue
+		}
+		check = uncheckedError(check)
+		if v.translator != nil {
+			check = translate(v.translator, check)
+		}
+		errs = append(errs, check)
+	}
+	return errs
+}
+
+// uncheckedError reports a field whose CustomCtx() rules did not run to the end
+func uncheckedError(field ValidationError) ValidationError {
+	field.Code = CodeUnchecked
+	field.Message = "was not checked, ValidateCtx() did not run to completion"
+	return field
+}
+
+// run runs the checks of the field, completed is false when ctx interrupted them
+func (f asyncField) run(ctx context.Context) (errs []ValidationError, completed bool) {
+	for _, check := range f.checks {
+		if ctx.Err() != nil {
+			return errs, false
+		}
+		err := check.fn(ctx, check.field.Value)
+		if err == nil {
+			continue
+		}
+		// an interrupted check is not a validation failure, the field is reported unchecked
+		if ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			return errs, false
+		}
+		errs = append(errs, customError(check.field, err))
+		if !f.allErrors {
+			return errs, true
+		}
+	}
+	return errs, true
+}
+
+// runFields runs the checks of several fields in order, completed is false when ctx interrupted them
+func runFields(ctx context.Context, fields []asyncField) (errs []ValidationError, completed bool) {
+	for _, field := range fields {
+		fieldErrs, completed := field.run(ctx)
+		errs = append(errs, fieldErrs...)
+		if !completed {
+			return errs, false
+		}
+	}
+	return errs, true
+}
+
+// customError turns the error of a custom rule into a ValidationError of field
+func customError(field ValidationError, err error) ValidationError {
+	var target ValidationError
+	if errors.As(err, &target) {
+		field.Code = target.Code
+		field.Message = target.Message
+		field.Params = target.Params
+	} else {
+		field.Code = CodeCustom
+		field.Message = err.Error()
+	}
+	if field.Code == "" {
+		field.Code = CodeCustom
+	}
+	return field
+}
@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// lookupStub is an in-memory stand-in for a database uniqueness check
+type lookupStub struct {
+	mu       sync.Mutex
+	taken    map[string]bool
+	delay    time.Duration
+	running  int32
+	maxInUse int32
+}
+
+func (s *lookupStub) check(ctx context.Context, value interface{}) error {
+	inUse := atomic.AddInt32(&s.running, 1)
+	defer atomic.AddInt32(&s.running, -1)
+	for {
+		max := atomic.LoadInt32(&s.maxInUse)
+		if inUse <= max || atomic.CompareAndSwapInt32(&s.maxInUse, max, inUse) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.taken[value.(string)] {
+		return errors.New("is already taken")
+	}
+	return nil
+}
+
+func TestCustomCtx(t *testing.T) {
+	stub := &lookupStub{taken: map[string]bool{"ctrix": true, "admin@example.com": true}}
+
+	v := NewValidator("username", "ctrix").Min(3).CustomCtx(stub.check)
+	v.NextField("email", "admin@example.com").Email().CustomCtx(stub.check)
+	v.NextField("nickname", "free").CustomCtx(stub.check)
+
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"username:unchecked", "email:unchecked", "nickname:unchecked"}) {
+		t.Fatalf("GetError() should report the checks ValidateCtx() has not run, got %v", got)
+	}
+	if err := v.ValidateCtx(context.Background()); err != nil {
+		t.Fatalf("ValidateCtx() = %v", err)
+	}
+
+	errs := v.GetError()
+	if len(errs) != 2 || errs[0].Field != "username" || errs[1].Field != "email" {
+		t.Fatalf("ValidateCtx() errors = %v", errs)
+	}
+	if errs[0].Code != CodeCustom || errs[0].Message != "is already taken" || errs[0].Value != "ctrix" {
+		t.Errorf("CustomCtx() error = %+v", errs[0])
+	}
+}
+
+func TestCustomCtxFirstErrorPerField(t *testing.T) {
+	calls := int32(0)
+	check := func(ctx context.Context, value interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return ValidationError{Code: "taken", Message: "is already taken"}
+	}
+
+	v := NewValidator("username", "ab").Min(3).CustomCtx(check)
+	v.NextField("email", "test@example.com").CustomCtx(check).CustomCtx(check)
+	v.NextField("code", "x1").CustomCtx(check).Alpha()
+	v.ValidateCtx(context.Background())
+
+	errs := v.GetError()
+	if calls != 1 {
+		t.Errorf("checks should be skipped after the first error of a field, ran %d", calls)
+	}
+	if len(errs) != 3 || errs[1].Field != "code" || errs[2].Field != "email" || errs[2].Code != "taken" {
+		t.Errorf("ValidateCtx() errors = %v", errs)
+	}
+}
+
+func TestValidateCtxConcurrency(t *testing.T) {
+	stub := &lookupStub{delay: 20 * time.Millisecond}
+
+	v := NewValidator("f0", "a").Workers(3).CustomCtx(stub.check)
+	for _, name := range []string{"f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9"} {
+		v.NextField(name, "a").CustomCtx(stub.check)
+	}
+
+	start := time.Now()
+	if err := v.ValidateCtx(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stub.maxInUse > 3 {
+		t.Errorf("ValidateCtx() ran %d checks at once; want at most 3", stub.maxInUse)
+	}
+	if stub.maxInUse < 2 || time.Since(start) > 180*time.Millisecond {
+		t.Errorf("ValidateCtx() should run independent fields concurrently")
+	}
+}
+
+func TestValidateCtxDeterministicOrder(t *testing.T) {
+	for run := 0; run < 10; run++ {
+		v := NewValidator("slow", "a").Workers(4).CustomCtx(func(ctx context.Context, value interface{}) error {
+			time.Sleep(5 * time.Millisecond)
+			return errors.New("slow failed")
+		})
+		v.NextField("fast", "b").CustomCtx(func(ctx context.Context, value interface{}) error {
+			return errors.New("fast failed")
+		})
+		v.ValidateCtx(context.Background())
+
+		errs := v.GetError()
+		if len(errs) != 2 || errs[0].Field != "slow" || errs[1].Field != "fast" {
+			t.Fatalf("ValidateCtx() should merge errors in declaration order, got %v", errs)
+		}
+	}
+}
+
+func TestValidateCtxDeadline(t *testing.T) {
+	stub := &lookupStub{delay: time.Second, taken: map[string]bool{"ctrix": true}}
+	v := NewValidator("username", "ctrix").CustomCtx(stub.check)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := v.ValidateCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ValidateCtx() = %v; want context.DeadlineExceeded", err)
+	}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"username:unchecked"}) {
+		t.Errorf("interrupted checks should be reported as unchecked, got %v", got)
+	}
+}
+
+func TestValidateCtxCancelledBeforeStart(t *testing.T) {
+	stub := &lookupStub{}
+	v := NewValidator("username", "ctrix").CustomCtx(stub.check).Workers(1)
+	v.NextField("email", "ann").Email().CustomCtx(stub.check)
+	v.NextField("nickname", "free").CustomCtx(stub.check)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := v.ValidateCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateCtx() = %v; want context.Canceled", err)
+	}
+	want := []string{"email:email", "username:unchecked", "nickname:unchecked"}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, want) {
+		t.Errorf("checks that never started should be reported as unchecked, got %v; want %v", got, want)
+	}
+}
+
+func TestSchemaValidateCtx(t *testing.T) {
+	stub := &lookupStub{taken: map[string]bool{"ctrix": true}}
+	schema := NewSchema(Field("username").Min(3).CustomCtx(stub.check)).Workers(2)
+
+	errs, err := schema.ValidateCtx(context.Background(), map[string]interface{}{"username": "ctrix"})
+	if err != nil || len(errs) != 1 || errs[0].Code != CodeCustom {
+		t.Errorf("Schema.ValidateCtx() = %v, %v", errs, err)
+	}
+	if errs := schema.Validate(map[string]interface{}{"username": "other"}); errs != nil {
+		t.Errorf("Schema.Validate() should run CustomCtx rules, got %v", errs)
+	}
+}
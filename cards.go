@@ -0,0 +1,311 @@
+package validator
+
+import (
+	"strings"
+	"time"
+)
+
+// Error codes of the payment card rules
+const (
+	CodeCardBrand   = "card_brand"
+	CodeCardExpiry  = "card_expiry"
+	CodeCardExpired = "card_expired"
+	CodeCVV         = "cvv"
+)
+
+// CardBrand is a payment card network, as detected by DetectCardBrand()
+type CardBrand string
+
+const (
+	Visa       CardBrand = "visa"
+	Mastercard CardBrand = "mastercard"
+	Amex       CardBrand = "amex"
+	Discover   CardBrand = "discover"
+	JCB        CardBrand = "jcb"
+	DinersClub CardBrand = "diners"
+	UnionPay   CardBrand = "unionpay"
+	Maestro    CardBrand = "maestro"
+)
+
+// cardPrefix is a range of leading digits, e.g. {2221, 2720, 4} for the 2-series of Mastercard
+type cardPrefix struct {
+	from, to int
+	digits   int // number of leading digits, the length of from
+}
+
+// maxCardDigits is the longest card number of any brand
+const maxCardDigits = 19
+
+// cardBrands lists the prefixes and lengths of each brand, the first match wins so that
+// co-branded Discover ranges are checked before the UnionPay 62 range
+var cardBrands = []struct {
+	brand    CardBrand
+	prefixes []cardPrefix
+	minLen   int
+	maxLen   int
+}{
+	{Amex, []cardPrefix{{34, 34, 2}, {37, 37, 2}}, 15, 15},
+	{DinersClub, []cardPrefix{{300, 305, 3}, {3095, 3095, 4}, {36, 36, 2}, {38, 39, 2}}, 14, 19},
+	{JCB, []cardPrefix{{3528, 3589, 4}}, 16, 19},
+	{Visa, []cardPrefix{{4, 4, 1}}, 13, 19},
+	{Mastercard, []cardPrefix{{51, 55, 2}, {2221, 2720, 4}}, 16, 16},
+	{Discover, []cardPrefix{{6011, 6011, 4}, {644, 649, 3}, {65, 65, 2}, {622126, 622925, 6}}, 16, 19},
+	{UnionPay, []cardPrefix{{62, 62, 2}, {81, 81, 2}}, 16, 19},
+	{Maestro, []cardPrefix{{5018, 5018, 4}, {5020, 5020, 4}, {5038, 5038, 4}, {5893, 5893, 4}, {6304, 6304, 4}, {6759, 6759, 4}, {6761, 6763, 4}}, 12, 19},
+}
+
+/*
+This function detects the brand of a card number, spaces and dashes between the digits are ignored
+
+returns: CardBrand, "" when the number belongs to no known brand
+
+The check digit is not verified, see Validator.CreditCard().
+
+Example:
+
+	brand := validator.DetectCardBrand("3782 822463 10005") // validator.Amex
+*/
+func DetectCardBrand(number string) CardBrand {
+	var buf [maxCardDigits]byte
+	digits, ok := cardDigits(buf[:0], number)
+	if !ok {
+		return ""
+	}
+	return cardBrandOf(digits)
+}
+
+// cardBrandOf detects the brand of the digits of a card number
+func cardBrandOf(digits []byte) CardBrand {
+	for _, b := range cardBrands {
+		if len(digits) < b.minLen || len(digits) > b.maxLen {
+			continue
+		}
+		for _, p := range b.prefixes {
+			if p.matches(digits) {
+				return b.brand
+			}
+		}
+	}
+	return ""
+}
+
+func knownCardBrand(brand CardBrand) bool {
+	for _, b := range cardBrands {
+		if b.brand == brand {
+			return true
+		}
+	}
+	return false
+}
+
+func (p cardPrefix) matches(digits []byte) bool {
+	if len(digits) < p.digits {
+		return false
+	}
+	prefix := 0
+	for _, c := range digits[:p.digits] {
+		prefix = prefix*10 + int(c-'0')
+	}
+	return prefix >= p.from && prefix <= p.to
+}
+
+// cardDigits appends the digits of a card number to dst, skipping the spaces and dashes users type between them
+func cardDigits(dst []byte, number string) ([]byte, bool) {
+	for i := 0; i < len(number); i++ {
+		switch c := number[i]; {
+		case c >= '0' && c <= '9':
+			dst = append(dst, c)
+		case c == ' ' || c == '-':
+		default:
+			return nil, false
+		}
+	}
+	return dst, len(dst) > 0
+}
+
+// luhn verifies the check digit of a card number
+func luhn(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+/*
+This function checks if the field is a valid payment card number
+
+- brands: the accepted brands, any known brand when none is given
+
+returns: *Validator
+
+Spaces and dashes between the digits are ignored, the check digit is verified with the Luhn algorithm
+and the number must belong to a known brand, see DetectCardBrand(). A valid number of another brand
+is reported with the card_brand code.
+
+Example:
+
+	validator := NewValidator("card", "4111 1111 1111 1111").CreditCard(validator.Visa, validator.Mastercard)
+*/
+func (v *Validator) CreditCard(brands ...CardBrand) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	data, ok := v.data.(string)
+	if !ok {
+		v.typeMismatch("string")
+		return v
+	}
+
+	// the number is normalised once, on the stack, for both the brand and the check digit
+	var buf [maxCardDigits]byte
+	digits, ok := cardDigits(buf[:0], data)
+	brand := CardBrand("")
+	if ok {
+		brand = cardBrandOf(digits)
+	}
+	if brand == "" || !luhn(digits) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeCreditCard,
+			Message: "must be a valid credit card number",
+			Value:   v.data,
+		})
+		return v
+	}
+	if len(brands) == 0 {
+		return v
+	}
+	for _, accepted := range brands {
+		if accepted == brand {
+			return v
+		}
+	}
+	names := make([]string, len(brands))
+	for i, accepted := range brands {
+		names[i] = string(accepted)
+	}
+	v.appendError(ValidationError{
+		Field:   v.fieldName,
+		Code:    CodeCardBrand,
+		Message: "must be a card of an accepted brand: " + strings.Join(names, ", "),
+		Params:  map[string]interface{}{"brands": strings.Join(names, ", "), "brand": string(brand)},
+		Value:   v.data,
+	})
+	return v
+}
+
+/*
+This function checks if a card expiring at the end of month/year is valid and not expired, according to the Clock()
+
+- month: 1 to 12
+
+- year: e.g. 2027, two digit years are read as 20YY
+
+returns: *Validator
+
+The value of the field only decides whether it is present, errors are reported on the field.
+
+Example:
+
+	validator.NextField("expiry", data.ExpMonth).CardExpiry(data.ExpMonth, data.ExpYear)
+*/
+func (v *Validator) CardExpiry(month int, year int) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	if year >= 0 && year < 100 {
+		year += 2000
+	}
+	if month < 1 || month > 12 || year < 1000 || year > 9999 {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeCardExpiry,
+			Message: "must be a valid expiry date",
+			Params:  map[string]interface{}{"month": month, "year": year},
+			Value:   v.data,
+		})
+		return v
+	}
+	// the card is valid through the last day of its expiry month
+	now := v.currentTime()
+	if !now.Before(time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, now.Location())) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeCardExpired,
+			Message: "must not be expired",
+			Params:  map[string]interface{}{"month": month, "year": year},
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+/*
+This function checks if the field is a card security code
+
+- brand: the brand of the card, 4 digits for Amex and 3 for the others, 3 or 4 digits when ""
+
+returns: *Validator
+
+Example:
+
+	brand := validator.DetectCardBrand(data.Number)
+	validator.NextField("number", data.Number).CreditCard()
+	validator.NextField("cvv", data.CVV).CVV(brand)
+*/
+func (v *Validator) CVV(brand CardBrand) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	data, ok := v.data.(string)
+	if !ok {
+		v.typeMismatch("string")
+		return v
+	}
+
+	valid := len(data) == 3 || len(data) == 4
+	switch brand {
+	case Amex:
+		valid = len(data) == 4
+	case "":
+	default:
+		valid = len(data) == 3
+	}
+	for _, c := range data {
+		valid = valid && c >= '0' && c <= '9'
+	}
+	if !valid {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeCVV,
+			Message: "must be a valid security code",
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+/*
+This function checks if the field is a card security code, see Validator.CVV()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) CVV(brand CardBrand) *SchemaField {
+	var args []interface{}
+	if brand != "" {
+		args = []interface{}{string(brand)}
+	}
+	return f.with(schemaRule{code: CodeCVV, args: args, apply: func(v *Validator) *Validator {
+		return v.CVV(brand)
+	}})
+}
@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   CardBrand
+	}{
+		{"4111111111111111", Visa},
+		{"4222222222222", Visa},
+		{"5555555555554444", Mastercard},
+		{"2223003122003222", Mastercard},
+		{"3782 822463 10005", Amex},
+		{"6011-1111-1111-1117", Discover},
+		{"6221260000000000", Discover},
+		{"3530111333300000", JCB},
+		{"30569309025904", DinersClub},
+		{"6200000000000005", UnionPay},
+		{"6205500000000000004", UnionPay},
+		{"6759649826438453", Maestro},
+		{"5018000000000009", Maestro},
+		{"1234567812345678", ""},
+		{"411111111111", ""},
+		{"4111x1111", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := DetectCardBrand(tt.number); got != tt.want {
+			t.Errorf("DetectCardBrand(%q) = %q; want %q", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestCardPrefixDigits(t *testing.T) {
+	for _, b := range cardBrands {
+		for _, p := range b.prefixes {
+			if want := len(strconv.Itoa(p.from)); p.digits != want || len(strconv.Itoa(p.to)) != want {
+				t.Errorf("%s prefix %+v should have %d digits", b.brand, p, want)
+			}
+		}
+	}
+}
+
+func TestCreditCardLuhn(t *testing.T) {
+	tests := []struct {
+		name   string
+		number interface{}
+		brands []CardBrand
+		want   []string
+	}{
+		{"valid", "4111111111111111", nil, []string{}},
+		{"spaces", "4111 1111 1111 1111", nil, []string{}},
+		{"dashes", "5555-5555-5555-4444", nil, []string{}},
+		{"wrong check digit", "4111111111111112", nil, []string{"card:creditcard"}},
+		{"unknown brand", "0000000000000000", nil, []string{"card:creditcard"}},
+		{"letters", "4111-abcd-1111-1111", nil, []string{"card:creditcard"}},
+		{"accepted brand", "378282246310005", []CardBrand{Visa, Amex}, []string{}},
+		{"rejected brand", "378282246310005", []CardBrand{Visa, Mastercard}, []string{"card:card_brand"}},
+		{"not a string", 4111111111111111, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewValidator("card", tt.number).CreditCard(tt.brands...).GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreditCard(%v) on %v = %v; want %v", tt.brands, tt.number, got, tt.want)
+			}
+		})
+	}
+
+	errs := NewValidator("card", "378282246310005").CreditCard(Visa, Mastercard).GetError()
+	if errs[0].Params["brands"] != "visa, mastercard" || errs[0].Params["brand"] != "amex" {
+		t.Errorf("card_brand params = %v", errs[0].Params)
+	}
+}
+
+func TestCardExpiry(t *testing.T) {
+	now := func() time.Time { return time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name        string
+		month, year int
+		want        []string
+	}{
+		{"this month", 5, 2024, []string{}},
+		{"two digit year", 5, 24, []string{}},
+		{"next year", 1, 2025, []string{}},
+		{"last month", 4, 2024, []string{"expiry:card_expired"}},
+		{"last year", 12, 23, []string{"expiry:card_expired"}},
+		{"month 0", 0, 2025, []string{"expiry:card_expiry"}},
+		{"month 13", 13, 2025, []string{"expiry:card_expiry"}},
+		{"three digit year", 1, 202, []string{"expiry:card_expiry"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewValidator("expiry", tt.month).Clock(now).CardExpiry(tt.month, tt.year).GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CardExpiry(%d, %d) = %v; want %v", tt.month, tt.year, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCVV(t *testing.T) {
+	tests := []struct {
+		cvv   string
+		brand CardBrand
+		want  []string
+	}{
+		{"123", Visa, []string{}},
+		{"1234", Visa, []string{"cvv:cvv"}},
+		{"1234", Amex, []string{}},
+		{"123", Amex, []string{"cvv:cvv"}},
+		{"123", "", []string{}},
+		{"1234", "", []string{}},
+		{"12a", "", []string{"cvv:cvv"}},
+		{"12", "", []string{"cvv:cvv"}},
+	}
+
+	for _, tt := range tests {
+		errs := NewValidator("cvv", tt.cvv).CVV(tt.brand).GetError()
+		if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CVV(%q) on %q = %v; want %v", tt.brand, tt.cvv, got, tt.want)
+		}
+	}
+}
+
+func TestCardRules(t *testing.T) {
+	type payment struct {
+		Number string `json:"number" validate:"creditcard=visa mastercard"`
+		CVV    string `json:"cvv" validate:"cvv=amex"`
+	}
+	errs := ValidateStruct(payment{Number: "3782 822463 10005", CVV: "123"})
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"number:card_brand", "cvv:cvv"}) {
+		t.Errorf("ValidateStruct() = %v", got)
+	}
+
+	for _, rules := range []string{"creditcard=visa paypal", "cvv=visa amex"} {
+		if _, err := ParseRules("field", rules); err == nil {
+			t.Errorf("ParseRules(%q) should fail", rules)
+		}
+	}
+}
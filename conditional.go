@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Error codes of the conditional rules
+const (
+	CodeRequiredIf      = "required_if"
+	CodeRequiredUnless  = "required_unless"
+	CodeRequiredWith    = "required_with"
+	CodeRequiredWithout = "required_without"
+	CodeExcludedIf      = "excluded_if"
+)
+
+/*
+This function makes the field required only if another field equals value, optional otherwise
+
+- fieldName: name of the other field
+
+- value: value the other field is compared with
+
+returns: *Validator
+
+Note: Like NotRequired(), call it before any other rule of the field.
+
+Example:
+
+	validator := NewValidator("country", data.Country).Min(2)
+	validator.NextField("state", data.State).RequiredIf("country", "US").Alpha()
+*/
+func (v *Validator) RequiredIf(fieldName string, value interface{}) *Validator {
+	return v.requiredWhen(v.fieldEquals(fieldName, value, CodeRequiredIf), CodeRequiredIf, func() (string, map[string]interface{}) {
+		return "Field is Required when " + fieldName + " is " + fmt.Sprint(value), map[string]interface{}{"other": fieldName, "value": value}
+	})
+}
+
+/*
+This function makes the field required unless another field equals value, optional otherwise
+
+returns: *Validator
+
+Note: Like NotRequired(), call it before any other rule of the field.
+*/
+func (v *Validator) RequiredUnless(fieldName string, value interface{}) *Validator {
+	return v.requiredWhen(!v.fieldEquals(fieldName, value, CodeRequiredUnless), CodeRequiredUnless, func() (string, map[string]interface{}) {
+		return "Field is Required unless " + fieldName + " is " + fmt.Sprint(value), map[string]interface{}{"other": fieldName, "value": value}
+	})
+}
+
+/*
+This function makes the field required if any of the other fields is present, optional otherwise
+
+returns: *Validator
+
+Note: Like NotRequired(), call it before any other rule of the field.
+*/
+func (v *Validator) RequiredWith(fieldNames ...string) *Validator {
+	required := false
+	for _, fieldName := range fieldNames {
+		if v.fieldPresent(fieldName, CodeRequiredWith) {
+			required = true
+		}
+	}
+	return v.requiredWhen(required, CodeRequiredWith, func() (string, map[string]interface{}) {
+		fields := strings.Join(fieldNames, ", ")
+		return "Field is Required when " + fields + " is present", map[string]interface{}{"fields": fields}
+	})
+}
+
+/*
+This function makes the field required if any of the other fields is missing, optional otherwise
+
+returns: *Validator
+
+Note: Like NotRequired(), call it before any other rule of the field.
+*/
+func (v *Validator) RequiredWithout(fieldNames ...string) *Validator {
+	required := false
+	for _, fieldName := range fieldNames {
+		if !v.fieldPresent(fieldName, CodeRequiredWithout) {
+			required = true
+		}
+	}
+	return v.requiredWhen(required, CodeRequiredWithout, func() (string, map[string]interface{}) {
+		fields := strings.Join(fieldNames, ", ")
+		return "Field is Required when " + fields + " is missing", map[string]interface{}{"fields": fields}
+	})
+}
+
+/*
+This function requires the field to be empty if another field equals value
+
+returns: *Validator
+
+Note: Call it before any other rule of the field, the field is optional when it applies.
+*/
+func (v *Validator) ExcludedIf(fieldName string, value interface{}) *Validator {
+	if v.fieldFailed() || !v.fieldEquals(fieldName, value, CodeExcludedIf) {
+		return v
+	}
+
+	v.requiredField = false
+	if !v.isEmpty() {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeExcludedIf,
+			Message: "must be empty when " + fieldName + " is " + fmt.Sprint(value),
+			Params:  map[string]interface{}{"other": fieldName, "value": value},
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+// requiredWhen makes the field required or optional and reports a missing required field right away,
+// describe builds the message and params of the error only when it is reported
+func (v *Validator) requiredWhen(required bool, code string, describe func() (string, map[string]interface{})) *Validator {
+	if v.fieldFailed() {
+		return v
+	}
+
+	v.requiredField = required
+	if required && v.isEmpty() {
+		message, params := describe()
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    code,
+			Message: message,
+			Params:  params,
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+// fieldEquals reports whether another field is present and equal to value.
+// A string value also matches the text form of the field, as parameters from struct tags are strings.
+func (v *Validator) fieldEquals(fieldName string, value interface{}, code string) bool {
+	other, ok := v.otherField(fieldName, code)
+	if !ok || other == nil {
+		return false
+	}
+
+	if cmp, ok := compareValues(other, value); ok {
+		return cmp == 0
+	}
+	if text, ok := value.(string); ok {
+		return fmt.Sprint(other) == text
+	}
+	return reflect.DeepEqual(other, value)
+}
+
+// fieldPresent reports whether another field is declared and not nil
+func (v *Validator) fieldPresent(fieldName string, code string) bool {
+	other, ok := v.otherField(fieldName, code)
+	return ok && !isNullish(other)
+}
+
+// otherField returns the value of the field a conditional rule refers to, like compareField()
+// it panics on a field that was never declared when there is no Schema to declare it later
+func (v *Validator) otherField(fieldName string, code string) (interface{}, bool) {
+	other, found := v.FieldValue(fieldName)
+	if !found && v.lookup == nil {
+		panic(fmt.Sprintf("validator: %s on field %s refers to the undeclared field %q", code, v.fieldName, fieldName))
+	}
+	return other, found
+}
@@ -0,0 +1,173 @@
+package validator
+
+import "testing"
+
+func TestWhen(t *testing.T) {
+	tests := []struct {
+		name     string
+		userType string
+		password string
+		expected bool
+	}{
+		{"admin strong", "admin", "secret@123456", true},
+		{"admin weak", "admin", "secret@1", false},
+		{"regular", "regular", "secret@1", true},
+		{"regular short", "regular", "secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("password", tt.password).
+				When(
+					func() bool { return tt.userType == "admin" },
+					func(v *Validator) *Validator { return v.Min(12).HasSpecialChar() },
+				).
+				Unless(
+					func() bool { return tt.userType == "admin" },
+					func(v *Validator) *Validator { return v.Min(8) },
+				)
+			if (v.GetError() == nil) != tt.expected {
+				t.Errorf("When/Unless for %s = %v; want %v", tt.name, v.GetError() == nil, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWhenNotRequired(t *testing.T) {
+	v := NewValidator("nickname", nil).When(
+		func() bool { return false },
+		func(v *Validator) *Validator { return v.Min(3) },
+	)
+	if v.GetError() != nil {
+		t.Errorf("When() with a false condition should not report a required error, got %v", v.GetError())
+	}
+
+	v = NewValidator("nickname", nil).When(
+		func() bool { return true },
+		func(v *Validator) *Validator { return v.NotRequired().Min(3) },
+	)
+	if v.GetError() != nil {
+		t.Errorf("When() should honour NotRequired() inside the validations, got %v", v.GetError())
+	}
+}
+
+func TestRequiredOnlyOnce(t *testing.T) {
+	errs := NewValidator("email", "").Email().Min(5).Max(30).GetError()
+	if len(errs) != 1 {
+		t.Errorf("a missing required field should be reported once, got %v", errs)
+	}
+}
+
+func TestRequiredIf(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		state    interface{}
+		expected bool
+		code     string
+	}{
+		{"us with state", "US", "CA", true, ""},
+		{"us without state", "US", nil, false, CodeRequiredIf},
+		{"other without state", "DE", nil, true, ""},
+		{"other with invalid state", "DE", "C4", false, CodeAlpha},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("country", tt.country).Alpha()
+			v.NextField("state", tt.state).RequiredIf("country", "US").Alpha()
+			errs := v.GetError()
+			if (errs == nil) != tt.expected {
+				t.Fatalf("RequiredIf for %s = %v; want %v", tt.name, errs == nil, tt.expected)
+			}
+			if errs != nil && errs[0].Code != tt.code {
+				t.Errorf("Code = %q; want %q", errs[0].Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestRequiredUnlessWithWithout(t *testing.T) {
+	v := NewValidator("country", "US")
+	v.NextField("vat_id", nil).RequiredUnless("country", "US")
+	v.NextField("street", "Main St 1")
+	v.NextField("zip", nil)
+	v.NextField("city", nil).RequiredWith("street", "zip")
+	v.NextField("email", nil)
+	v.NextField("phone", nil).RequiredWithout("email")
+
+	errs := v.GetError()
+	if len(errs) != 2 || errs[0].Code != CodeRequiredWith || errs[1].Code != CodeRequiredWithout {
+		t.Fatalf("expected required_with and required_without errors, got %v", errs)
+	}
+	if errs[1].Message != "Field is Required when email is missing" {
+		t.Errorf("RequiredWithout() message = %q", errs[1].Message)
+	}
+}
+
+func TestConditionalUnknownField(t *testing.T) {
+	rules := map[string]func(v *Validator) *Validator{
+		"RequiredIf":      func(v *Validator) *Validator { return v.RequiredIf("countryy", "US") },
+		"RequiredUnless":  func(v *Validator) *Validator { return v.RequiredUnless("countryy", "US") },
+		"RequiredWith":    func(v *Validator) *Validator { return v.RequiredWith("country", "zip") },
+		"RequiredWithout": func(v *Validator) *Validator { return v.RequiredWithout("country", "zip") },
+		"ExcludedIf":      func(v *Validator) *Validator { return v.ExcludedIf("countryy", "US") },
+	}
+	for name, rule := range rules {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() should panic for a field that was never declared", name)
+				}
+			}()
+			v := NewValidator("country", "US")
+			rule(v.NextField("state", "CA"))
+		})
+	}
+}
+
+func TestExcludedIf(t *testing.T) {
+	v := NewValidator("payment", "cash")
+	v.NextField("card_number", "4111111111111111").ExcludedIf("payment", "cash").CreditCard()
+	if errs := v.GetError(); len(errs) != 1 || errs[0].Code != CodeExcludedIf {
+		t.Errorf("ExcludedIf() should report a non-empty field, got %v", errs)
+	}
+
+	v = NewValidator("payment", "cash")
+	v.NextField("card_number", nil).ExcludedIf("payment", "cash").CreditCard()
+	if v.GetError() != nil {
+		t.Errorf("ExcludedIf() should make the excluded field optional, got %v", v.GetError())
+	}
+}
+
+func TestSchemaConditional(t *testing.T) {
+	schema := NewSchema(
+		Field("country").Alpha(),
+		Field("state").RequiredIf("country", "US").Alpha(),
+		Field("password").When(
+			func(v *Validator) bool {
+				userType, _ := v.FieldValue("user_type")
+				return userType == "admin"
+			},
+			Elem().Min(12),
+		),
+	)
+
+	errs := schema.Validate(map[string]interface{}{"country": "US", "password": "secret", "user_type": "admin"})
+	if len(errs) != 2 || errs[0].Code != CodeRequiredIf || errs[1].Code != CodeMin {
+		t.Errorf("Schema conditional rules = %v", errs)
+	}
+
+	errs = schema.Validate(map[string]interface{}{"country": "DE", "password": "secret"})
+	if errs != nil {
+		t.Errorf("Schema conditional rules should not apply, got %v", errs)
+	}
+
+	type address struct {
+		Country string  `json:"country"`
+		Zip     *string `json:"zip" validate:"required_if=country US,numeric"`
+	}
+	if errs := ValidateStruct(address{Country: "US"}); len(errs) != 1 || errs[0].Code != CodeRequiredIf {
+		t.Errorf("required_if tag = %v", errs)
+	}
+}
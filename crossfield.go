@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Error codes of the cross-field rules
+const (
+	CodeEqField  = "eq_field"
+	CodeNeField  = "ne_field"
+	CodeGtField  = "gt_field"
+	CodeGteField = "gte_field"
+	CodeLtField  = "lt_field"
+	CodeLteField = "lte_field"
+)
+
+// namedValue is the value a field had when the validator moved on to the next field
+type namedValue struct {
+	name  string
+	value interface{}
+}
+
+// remember records the current field, so that later fields can refer to it by name
+func (v *Validator) remember() {
+	if v.fieldName == "" {
+		return
+	}
+	v.rememberValue(v.fieldName, v.data)
+}
+
+func (v *Validator) rememberValue(name string, value interface{}) {
+	for i := range v.values {
+		if v.values[i].name == name {
+			v.values[i].value = value
+			return
+		}
+	}
+	v.values = append(v.values, namedValue{name: name, value: value})
+}
+
+// siblingName returns the full name of a field declared in the current scope
+func (v *Validator) siblingName(fieldName string) string {
+	if len(v.scopePath) == 0 {
+		return fieldName
+	}
+	return renderPath(append(v.scopePath[:len(v.scopePath):len(v.scopePath)], pathSegment{key: fieldName, index: -1}))
+}
+
+/*
+This function returns the value of a previously declared field
+
+- fieldName: name of a sibling in the current Nested/Each scope, or the full path of any field
+
+returns: the value and whether the field was found
+*/
+func (v *Validator) FieldValue(fieldName string) (interface{}, bool) {
+	for i, name := range [...]string{v.siblingName(fieldName), fieldName} {
+		if name == v.fieldName {
+			return v.data, true
+		}
+		for i := len(v.values) - 1; i >= 0; i-- {
+			if v.values[i].name == name {
+				return v.values[i].value, true
+			}
+		}
+		// fields of the map or struct validated by a Schema are known before they are declared
+		if i == 0 && v.lookup != nil {
+			if value, ok := v.lookup(fieldName); ok {
+				return deref(value), true
+			}
+		}
+	}
+	return nil, false
+}
+
+/*
+This function checks if the field is equal to another field
+
+- fieldName: name of the other field, e.g. EqField("password") on "confirm_password"
+
+returns: *Validator
+*/
+func (v *Validator) EqField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeEqField, "must be equal to ", func(cmp int) bool { return cmp == 0 })
+}
+
+/*
+This function checks if the field is not equal to another field
+
+returns: *Validator
+*/
+func (v *Validator) NeField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeNeField, "must not be equal to ", func(cmp int) bool { return cmp != 0 })
+}
+
+/*
+This function checks if the field is greater than another field, e.g. GtField("start_date") on "end_date"
+
+returns: *Validator
+*/
+func (v *Validator) GtField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeGtField, "must be greater than ", func(cmp int) bool { return cmp > 0 })
+}
+
+/*
+This function checks if the field is greater than or equal to another field
+
+returns: *Validator
+*/
+func (v *Validator) GteField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeGteField, "must be greater than or equal to ", func(cmp int) bool { return cmp >= 0 })
+}
+
+/*
+This function checks if the field is less than another field
+
+returns: *Validator
+*/
+func (v *Validator) LtField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeLtField, "must be less than ", func(cmp int) bool { return cmp < 0 })
+}
+
+/*
+This function checks if the field is less than or equal to another field
+
+returns: *Validator
+*/
+func (v *Validator) LteField(fieldName string) *Validator {
+	return v.compareField(fieldName, CodeLteField, "must be less than or equal to ", func(cmp int) bool { return cmp <= 0 })
+}
+
+// compareField fails the field when the other field is present and ok(cmp) is false.
+// Eq/Ne fall back to reflect.DeepEqual for values that cannot be ordered, ordering rules skip them.
+func (v *Validator) compareField(fieldName string, code string, message string, ok func(cmp int) bool) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	other, found := v.FieldValue(fieldName)
+	if !found && v.lookup == nil {
+		// without a Schema every field the rule can refer to has been declared already
+		panic(fmt.Sprintf("validator: %s on field %s refers to the undeclared field %q", code, v.fieldName, fieldName))
+	}
+	if !found || other == nil {
+		// the other field is absent from the payload, required rules report it
+		return v
+	}
+
+	cmp, comparable := compareValues(v.data, other)
+	if !comparable {
+		if code != CodeEqField && code != CodeNeField {
+			v.typeMismatch(fmt.Sprintf("%T", other))
+			return v
+		}
+		cmp = 1
+		if reflect.DeepEqual(v.data, other) {
+			cmp = 0
+		}
+	}
+
+	if !ok(cmp) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    code,
+			Message: message + fieldName,
+			Params:  map[string]interface{}{"other": fieldName},
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+// fieldRefs returns the names of the other fields the cross-field and conditional rules of f refer to
+func (f *SchemaField) fieldRefs() []string {
+	var refs []string
+	for _, r := range f.rules {
+		switch r.code {
+		case CodeEqField, CodeNeField, CodeGtField, CodeGteField, CodeLtField, CodeLteField,
+			CodeRequiredIf, CodeRequiredUnless, CodeExcludedIf:
+			refs = append(refs, r.args[0].(string))
+		case CodeRequiredWith, CodeRequiredWithout:
+			refs = append(refs, r.args[0].([]string)...)
+		case "when", "any_of":
+			for _, arg := range r.args {
+				refs = append(refs, arg.(*SchemaField).fieldRefs()...)
+			}
+		}
+	}
+	return refs
+}
+
+// compareValues orders two strings, two numbers of any kind or two time.Time values
+func compareValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), true
+		}
+		return 0, false
+	}
+	return compareNumbers(a, b)
+}
+
+// compareNumbers compares numbers of any kind without losing precision between integer kinds
+func compareNumbers(a, b interface{}) (int, bool) {
+	ai, aSigned, aInt := integerValue(a)
+	bi, bSigned, bInt := integerValue(b)
+	if aInt && bInt {
+		switch {
+		case aSigned && !bSigned && int64(ai) < 0:
+			return -1, true
+		case !aSigned && bSigned && int64(bi) < 0:
+			return 1, true
+		case aSigned && bSigned:
+			return compareInt64(int64(ai), int64(bi)), true
+		}
+		return compareUint64Values(ai, bi), true
+	}
+
+	af, ok := toFloat64(a)
+	if !ok {
+		return 0, false
+	}
+	bf, ok := toFloat64(b)
+	if !ok || math.IsNaN(af) || math.IsNaN(bf) {
+		return 0, false
+	}
+	return compareFloat64(af, bf), true
+}
+
+func compareUint64Values(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// integerValue returns the bits of an integer of any kind and whether it is signed
+func integerValue(value interface{}) (bits uint64, signed bool, ok bool) {
+	switch n := value.(type) {
+	case int:
+		return uint64(n), true, true
+	case int8:
+		return uint64(n), true, true
+	case int16:
+		return uint64(n), true, true
+	case int32:
+		return uint64(n), true, true
+	case int64:
+		return uint64(n), true, true
+	case uint:
+		return uint64(n), false, true
+	case uint8:
+		return uint64(n), false, true
+	case uint16:
+		return uint64(n), false, true
+	case uint32:
+		return uint64(n), false, true
+	case uint64:
+		return n, false, true
+	}
+	return 0, false, false
+}
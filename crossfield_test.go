@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqField(t *testing.T) {
+	v := NewValidator("password", "secret@123").Min(8)
+	v.NextField("confirm_password", "secret@123").EqField("password")
+	if v.GetError() != nil {
+		t.Errorf("EqField() should not return an error for equal fields, got %v", v.GetError())
+	}
+
+	v = NewValidator("password", "secret@123").Min(8)
+	v.NextField("confirm_password", "secret@124").EqField("password")
+	errs := v.GetError()
+	if len(errs) != 1 || errs[0].Code != CodeEqField || errs[0].Params["other"] != "password" {
+		t.Errorf("EqField() should report eq_field, got %v", errs)
+	}
+	if errs[0].Message != "must be equal to password" {
+		t.Errorf("EqField() message = %q", errs[0].Message)
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		first    interface{}
+		second   interface{}
+		rule     func(*Validator) *Validator
+		expected bool
+	}{
+		{"ne strings", "old", "new", func(v *Validator) *Validator { return v.NeField("first") }, true},
+		{"ne equal strings", "same", "same", func(v *Validator) *Validator { return v.NeField("first") }, false},
+		{"gt times", start, start.Add(time.Hour), func(v *Validator) *Validator { return v.GtField("first") }, true},
+		{"gt equal times", start, start, func(v *Validator) *Validator { return v.GtField("first") }, false},
+		{"gte mixed numbers", 10, float32(10), func(v *Validator) *Validator { return v.GteField("first") }, true},
+		{"lt int and uint64", -1, uint64(1 << 63), func(v *Validator) *Validator { return v.LtField("first") }, false},
+		{"gt int and uint64", -1, uint64(1 << 63), func(v *Validator) *Validator { return v.GtField("first") }, true},
+		{"lte int64", int64(5), int8(6), func(v *Validator) *Validator { return v.LteField("first") }, false},
+		{"eq int and float", 3, 3.0, func(v *Validator) *Validator { return v.EqField("first") }, true},
+		{"eq slices", []string{"a"}, []string{"a"}, func(v *Validator) *Validator { return v.EqField("first") }, true},
+		{"gt mismatched types skipped", "a", 2, func(v *Validator) *Validator { return v.GtField("first") }, true},
+		{"absent field skipped", nil, 2, func(v *Validator) *Validator { return v.EqField("first") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("first", tt.first)
+			tt.rule(v.NextField("second", tt.second))
+			if (v.GetError() == nil) != tt.expected {
+				t.Errorf("%s = %v; want %v (%v)", tt.name, v.GetError() == nil, tt.expected, v.GetError())
+			}
+		})
+	}
+}
+
+func TestCompareFieldsTransformed(t *testing.T) {
+	v := NewValidator("email", " Test@Example.com ").Transform(func(data interface{}) interface{} {
+		return "test@example.com"
+	})
+	v.NextField("confirm_email", "test@example.com").EqField("email")
+	if v.GetError() != nil {
+		t.Errorf("EqField() should compare with the transformed value, got %v", v.GetError())
+	}
+}
+
+func TestCompareFieldsNested(t *testing.T) {
+	v := NewValidator("name", "ctrix").Nested("period", func(v *Validator) {
+		v.NextField("start", 10)
+		v.NextField("end", 5).GtField("start")
+	})
+	v.NextField("limit", 7).LteField("period.end")
+
+	errs := v.GetError()
+	if len(errs) != 2 || errs[0].Field != "period.end" || errs[1].Field != "limit" {
+		t.Errorf("cross-field rules should resolve siblings and full paths, got %v", errs)
+	}
+}
+
+func TestSchemaCrossFieldForwardReference(t *testing.T) {
+	schema := NewSchema(
+		Field("confirm_password").EqField("password"),
+		Field("password").Min(8),
+	)
+	errs := schema.Validate(map[string]interface{}{"password": "secret@123", "confirm_password": "secret"})
+	if len(errs) != 1 || errs[0].Field != "confirm_password" {
+		t.Errorf("Schema cross-field rules should see fields declared later, got %v", errs)
+	}
+
+	type period struct {
+		Start int `json:"start"`
+		End   int `json:"end" validate:"gt_field=start"`
+	}
+	if errs := ValidateStruct(period{Start: 5, End: 1}); len(errs) != 1 || errs[0].Code != CodeGtField {
+		t.Errorf("gt_field tag should compare fields, got %v", errs)
+	}
+}
+
+func TestCompareFieldsUnknownField(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("EqField() should panic for a field that was never declared")
+		}
+	}()
+	v := NewValidator("password", "secret@123")
+	v.NextField("confirm_password", "secret@123").EqField("passwrod")
+}
+
+func TestSchemaCrossFieldAbsent(t *testing.T) {
+	schema := NewSchema(
+		Field("password").NotRequired(),
+		Field("confirm_password").NotRequired().EqField("password"),
+	)
+	if errs := schema.Validate(map[string]interface{}{"confirm_password": "secret"}); errs != nil {
+		t.Errorf("a field absent from the payload should be skipped, got %v", errs)
+	}
+
+	type signup struct {
+		Password string  `json:"password"`
+		Confirm  *string `json:"confirm" validate:"omitempty,eq_field=password"`
+	}
+	if errs := ValidateStruct(signup{Password: "secret"}); errs != nil {
+		t.Errorf("eq_field on an absent field should pass, got %v", errs)
+	}
+}
@@ -0,0 +1,307 @@
+package validator
+
+import (
+	"strings"
+	"time"
+)
+
+// Error codes of the date range rules
+const (
+	CodeBefore     = "before"
+	CodeAfter      = "after"
+	CodeBetween    = "between"
+	CodeFuture     = "future"
+	CodePast       = "past"
+	CodeWithinLast = "within_last"
+)
+
+// defaultDateLayouts parse strings in the range rules of a field without a Date() rule
+var defaultDateLayouts = []string{time.RFC3339, time.DateOnly}
+
+// dateOnlyLayouts are the layouts of a Date() rule without any, shared so that the rule does not allocate
+var dateOnlyLayouts = []string{time.DateOnly}
+
+/*
+This function checks if the field is a valid date, parsing strings with time.Parse so that
+impossible dates such as 2024-02-31 fail
+
+- layouts: accepted layouts, e.g. time.RFC3339 or "02.01.2006", time.DateOnly when none is given
+
+returns: *Validator
+
+time.Time values are always valid. The range rules of the field, e.g. Before() or Future(),
+parse strings with the same layouts.
+
+Example:
+
+	validator := NewValidator("birthday", data.Birthday).Date().Past()
+	validator.NextField("startsAt", data.StartsAt).Date(time.RFC3339).Future()
+*/
+func (v *Validator) Date(layouts ...string) *Validator {
+	if len(layouts) == 0 {
+		layouts = dateOnlyLayouts
+	}
+	v.dateLayouts = layouts
+	if v.commonReturnCase() {
+		return v
+	}
+
+	switch data := v.data.(type) {
+	case time.Time:
+	case string:
+		if _, ok := parseTime(data, layouts); !ok {
+			v.invalidDate()
+		}
+	default:
+		v.typeMismatch("string")
+	}
+	return v
+}
+
+func (v *Validator) invalidDate() {
+	err := ValidationError{
+		Field:   v.fieldName,
+		Code:    CodeDate,
+		Message: "must be a valid date",
+		Value:   v.data,
+	}
+	if layouts := v.dateLayouts; layouts != nil && !(len(layouts) == 1 && layouts[0] == time.DateOnly) {
+		err.Params = map[string]interface{}{"layout": strings.Join(layouts, " or ")}
+	}
+	v.appendError(err)
+}
+
+func parseTime(s string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+/*
+This function sets the clock of Future(), Past() and WithinLast(), for the rest of the validator
+
+- now: returns the current time, time.Now by default
+
+returns: *Validator
+
+Example:
+
+	fixed := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	validator := NewValidator("expiresAt", data.ExpiresAt).Clock(func() time.Time { return fixed }).Future()
+*/
+func (v *Validator) Clock(now func() time.Time) *Validator {
+	v.now = now
+	return v
+}
+
+func (v *Validator) currentTime() time.Time {
+	if v.now != nil {
+		return v.now()
+	}
+	return time.Now()
+}
+
+// timeValue returns the current field as a time, parsing strings with the layouts of its Date() rule
+// and reporting a date error when they do not parse
+func (v *Validator) timeValue() (time.Time, bool) {
+	switch data := v.data.(type) {
+	case time.Time:
+		return data, true
+	case string:
+		layouts := v.dateLayouts
+		if layouts == nil {
+			layouts = defaultDateLayouts
+		}
+		if t, ok := parseTime(data, layouts); ok {
+			return t, true
+		}
+		v.invalidDate()
+	default:
+		v.typeMismatch("time")
+	}
+	return time.Time{}, false
+}
+
+// checkTime reports err when the field is a time for which valid returns false
+func (v *Validator) checkTime(valid func(t time.Time) bool, err ValidationError) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	t, ok := v.timeValue()
+	if !ok || valid(t) {
+		return v
+	}
+	err.Field = v.fieldName
+	err.Value = v.data
+	v.appendError(err)
+	return v
+}
+
+/*
+This function checks if the date is strictly before t
+
+returns: *Validator
+*/
+func (v *Validator) Before(t time.Time) *Validator {
+	return v.checkTime(func(value time.Time) bool { return value.Before(t) }, ValidationError{
+		Code:    CodeBefore,
+		Message: "must be before " + formatParam(t),
+		Params:  map[string]interface{}{"before": t},
+	})
+}
+
+/*
+This function checks if the date is strictly after t
+
+returns: *Validator
+*/
+func (v *Validator) After(t time.Time) *Validator {
+	return v.checkTime(func(value time.Time) bool { return value.After(t) }, ValidationError{
+		Code:    CodeAfter,
+		Message: "must be after " + formatParam(t),
+		Params:  map[string]interface{}{"after": t},
+	})
+}
+
+/*
+This function checks if the date is between start and end, both included
+
+returns: *Validator
+*/
+func (v *Validator) Between(start time.Time, end time.Time) *Validator {
+	return v.checkTime(func(value time.Time) bool { return !value.Before(start) && !value.After(end) }, ValidationError{
+		Code:    CodeBetween,
+		Message: "must be between " + formatParam(start) + " and " + formatParam(end),
+		Params:  map[string]interface{}{"min": start, "max": end},
+	})
+}
+
+/*
+This function checks if the date is after the current time of the Clock()
+
+returns: *Validator
+*/
+func (v *Validator) Future() *Validator {
+	now := v.currentTime()
+	return v.checkTime(func(value time.Time) bool { return value.After(now) }, ValidationError{
+		Code:    CodeFuture,
+		Message: "must be in the future",
+	})
+}
+
+/*
+This function checks if the date is before the current time of the Clock()
+
+returns: *Validator
+*/
+func (v *Validator) Past() *Validator {
+	now := v.currentTime()
+	return v.checkTime(func(value time.Time) bool { return value.Before(now) }, ValidationError{
+		Code:    CodePast,
+		Message: "must be in the past",
+	})
+}
+
+/*
+This function checks if the date is in the past, at most d ago, according to the Clock()
+
+- d: e.g. 24 * time.Hour
+
+returns: *Validator
+*/
+func (v *Validator) WithinLast(d time.Duration) *Validator {
+	now := v.currentTime()
+	return v.checkTime(func(value time.Time) bool { return !value.After(now) && !value.Before(now.Add(-d)) }, ValidationError{
+		Code:    CodeWithinLast,
+		Message: "must be within the last " + formatDuration(d),
+		Params:  map[string]interface{}{"duration": d},
+	})
+}
+
+// formatDuration drops the zero units time.Duration.String() ends with, e.g. "24h" instead of "24h0m0s"
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+/*
+This function checks if the date is strictly before t
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Before(t time.Time) *SchemaField {
+	return f.with(schemaRule{code: CodeBefore, args: []interface{}{t}, apply: func(v *Validator) *Validator {
+		return v.Before(t)
+	}})
+}
+
+/*
+This function checks if the date is strictly after t
+
+returns: *SchemaField
+*/
+func (f *SchemaField) After(t time.Time) *SchemaField {
+	return f.with(schemaRule{code: CodeAfter, args: []interface{}{t}, apply: func(v *Validator) *Validator {
+		return v.After(t)
+	}})
+}
+
+/*
+This function checks if the date is between start and end, both included
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Between(start time.Time, end time.Time) *SchemaField {
+	return f.with(schemaRule{code: CodeBetween, args: []interface{}{start, end}, apply: func(v *Validator) *Validator {
+		return v.Between(start, end)
+	}})
+}
+
+/*
+This function checks if the date is in the future, see Schema.Clock()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Future() *SchemaField {
+	return f.with(schemaRule{code: CodeFuture, apply: (*Validator).Future})
+}
+
+/*
+This function checks if the date is in the past, see Schema.Clock()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Past() *SchemaField {
+	return f.with(schemaRule{code: CodePast, apply: (*Validator).Past})
+}
+
+/*
+This function checks if the date is in the past, at most d ago, see Schema.Clock()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) WithinLast(d time.Duration) *SchemaField {
+	return f.with(schemaRule{code: CodeWithinLast, args: []interface{}{d}, apply: func(v *Validator) *Validator {
+		return v.WithinLast(d)
+	}})
+}
+
+/*
+This function sets the clock of the Future(), Past() and WithinLast() rules, see Validator.Clock()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) Clock(now func() time.Time) *Schema {
+	schema := *s
+	schema.now = now
+	return &schema
+}
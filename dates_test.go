@@ -0,0 +1,188 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var fixedNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func fixedClock() time.Time {
+	return fixedNow
+}
+
+func TestDateLayouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		layouts []string
+		want    []string
+	}{
+		{"valid", "2024-05-01", nil, []string{}},
+		{"leap day", "2024-02-29", nil, []string{}},
+		{"no leap day", "2023-02-29", nil, []string{"field:date"}},
+		{"impossible day", "2024-02-31", nil, []string{"field:date"}},
+		{"impossible month", "2024-13-01", nil, []string{"field:date"}},
+		{"not a date", "yesterday", nil, []string{"field:date"}},
+		{"time value", fixedNow, nil, []string{}},
+		{"wrong type", 20240501, nil, []string{}},
+		{"RFC 3339", "2024-05-01T10:00:00+02:00", []string{time.RFC3339}, []string{}},
+		{"date for RFC 3339", "2024-05-01", []string{time.RFC3339}, []string{"field:date"}},
+		{"custom layout", "31.12.2024", []string{"02.01.2006"}, []string{}},
+		{"any layout", "2024-12-31", []string{"02.01.2006", time.DateOnly}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewValidator("field", tt.value).Date(tt.layouts...).GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Date(%v) on %v = %v; want %v", tt.layouts, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateLayoutParam(t *testing.T) {
+	errs := NewValidator("field", "2024-05-01").Date(time.RFC3339).GetError()
+	if len(errs) != 1 || errs[0].Params["layout"] != time.RFC3339 {
+		t.Fatalf("Date(RFC3339) errors = %v", errs)
+	}
+	errs = NewValidator("field", "2024-02-31").Date().GetError()
+	if len(errs) != 1 || errs[0].Params != nil {
+		t.Errorf("Date() errors = %v; want no layout param", errs)
+	}
+}
+
+func TestDateRanges(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value interface{}
+		rule  func(v *Validator) *Validator
+		want  []string
+	}{
+		{"before", "2023-12-31", func(v *Validator) *Validator { return v.Before(start) }, []string{}},
+		{"before, same day", "2024-01-01", func(v *Validator) *Validator { return v.Before(start) }, []string{"field:before"}},
+		{"after", "2024-01-02", func(v *Validator) *Validator { return v.After(start) }, []string{}},
+		{"after, same day", "2024-01-01", func(v *Validator) *Validator { return v.After(start) }, []string{"field:after"}},
+		{"between, first day", "2024-01-01", func(v *Validator) *Validator { return v.Between(start, end) }, []string{}},
+		{"between, last day", end, func(v *Validator) *Validator { return v.Between(start, end) }, []string{}},
+		{"between, outside", "2025-01-01", func(v *Validator) *Validator { return v.Between(start, end) }, []string{"field:between"}},
+		{"future", "2024-05-02", (*Validator).Future, []string{}},
+		{"future, past", "2024-04-30", (*Validator).Future, []string{"field:future"}},
+		{"past", "2024-05-01T11:59:59Z", (*Validator).Past, []string{}},
+		{"past, future", "2024-05-01T12:00:01Z", (*Validator).Past, []string{"field:past"}},
+		{"within last", "2024-04-30T13:00:00Z", func(v *Validator) *Validator { return v.WithinLast(24 * time.Hour) }, []string{}},
+		{"within last, too old", "2024-04-30T11:00:00Z", func(v *Validator) *Validator { return v.WithinLast(24 * time.Hour) }, []string{"field:within_last"}},
+		{"within last, future", "2024-05-02", func(v *Validator) *Validator { return v.WithinLast(24 * time.Hour) }, []string{"field:within_last"}},
+		{"invalid date", "2024-02-31", (*Validator).Past, []string{"field:date"}},
+		{"wrong type", 42, (*Validator).Past, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.rule(NewValidator("field", tt.value).Clock(fixedClock)).GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetError() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateStrict(t *testing.T) {
+	if got := errorCodes(NewValidator("field", 20240501).Strict().Date().GetError()); !reflect.DeepEqual(got, []string{"field:type_mismatch"}) {
+		t.Errorf("Strict().Date() on an int = %v", got)
+	}
+	if got := errorCodes(NewValidator("field", 42).Strict().Past().GetError()); !reflect.DeepEqual(got, []string{"field:type_mismatch"}) {
+		t.Errorf("Strict().Past() on an int = %v", got)
+	}
+}
+
+func TestDateRangesUseDateLayouts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if errs := NewValidator("field", "02.01.2024").Date("02.01.2006").After(start).GetError(); errs != nil {
+		t.Errorf("After() with a Date() layout errors = %v", errs)
+	}
+	v := NewValidator("field", "02.01.2024").Date("02.01.2006")
+	if errs := v.NextField("other", "02.01.2024").After(start).GetError(); len(errs) != 1 || errs[0].Code != CodeDate {
+		t.Errorf("NextField() should reset the layouts, errors = %v", errs)
+	}
+}
+
+func TestDateMessages(t *testing.T) {
+	errs := NewValidator("field", "2024-04-29").Clock(fixedClock).WithinLast(24 * time.Hour).GetError()
+	if len(errs) != 1 || errs[0].Message != "must be within the last 24h" {
+		t.Fatalf("WithinLast() errors = %v", errs)
+	}
+	errs = NewValidator("field", "2024-04-29").Locale("de").Before(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).GetError()
+	if len(errs) != 1 || errs[0].Message != "muss vor 2024-01-01T00:00:00Z liegen" {
+		t.Errorf("Before() de errors = %v", errs)
+	}
+}
+
+func TestDateSchema(t *testing.T) {
+	schema := NewSchema(
+		Field("birthday").Date().Past(),
+		Field("startsAt").Date(time.RFC3339).Future(),
+		Field("seenAt").NotRequired().WithinLast(time.Hour),
+	).Clock(fixedClock)
+
+	errs := schema.Validate(map[string]interface{}{
+		"birthday": "1990-02-29",
+		"startsAt": "2024-05-01T11:00:00Z",
+		"seenAt":   fixedNow.Add(-2 * time.Hour),
+	})
+	want := []string{"birthday:date", "startsAt:future", "seenAt:within_last"}
+	if got := errorCodes(errs); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v; want %v", got, want)
+	}
+}
+
+func TestDateRules(t *testing.T) {
+	tests := []struct {
+		rules string
+		value interface{}
+		want  []string
+	}{
+		{"date", "2024-02-31", []string{"field:date"}},
+		{"date=RFC3339", "2024-05-01T10:00:00Z", []string{}},
+		{"date=02.01.2006", "31.12.2024", []string{}},
+		{"before=2024-01-01", "2024-01-01", []string{"field:before"}},
+		{"after=2024-01-01T00:00:00Z", "2024-01-02", []string{}},
+		{"between=2024-01-01 2024-12-31", "2025-01-01", []string{"field:between"}},
+		{"past", "2000-01-01", []string{}},
+		{"within_last=24h", "2000-01-01", []string{"field:within_last"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			errs := NewSchema(MustParseRules("field", tt.rules)).Validate(map[string]interface{}{"field": tt.value})
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, rules := range []string{"before=yesterday", "between=2024-01-01", "within_last=1y", "future=now"} {
+		if _, err := ParseRules("field", rules); err == nil {
+			t.Errorf("ParseRules(%q) should fail", rules)
+		}
+	}
+}
+
+func TestDateJSONSchema(t *testing.T) {
+	doc := NewSchema(
+		Field("day").Date(),
+		Field("at").Date(time.RFC3339),
+	).JSONSchema()
+	properties := doc["properties"].(map[string]interface{})
+	if got := properties["day"].(map[string]interface{})["format"]; got != "date" {
+		t.Errorf("Date() format = %v", got)
+	}
+	if got := properties["at"].(map[string]interface{})["format"]; got != "date-time" {
+		t.Errorf("Date(RFC3339) format = %v", got)
+	}
+}
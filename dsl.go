@@ -0,0 +1,546 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ParseError reports an invalid rule string
+
+Pos is the byte offset in Rules of the rule or parameter at fault.
+*/
+type ParseError struct {
+	Rules string
+	Pos   int
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("validator: %s at position %d in %q", e.Msg, e.Pos, e.Rules)
+}
+
+// ruleToken is a single rule of a rule string, e.g. max=30
+type ruleToken struct {
+	name     string
+	param    string
+	hasParam bool
+	quoted   bool
+	pos      int // offset of the name
+	paramPos int // offset of the parameter
+}
+
+/*
+This function parses a rule string into the rules of a field, for rules stored in configuration
+
+- name: name of the field, "" for an anonymous chain as returned by Elem()
+
+- rules: the rules, e.g. "required,email,max=30" or "omitempty,min=8,has_special"
+
+- returns: *SchemaField, and a *ParseError for an unknown rule, a missing or malformed parameter or a syntax error
+
+The syntax is the one of struct tags:
+
+  - rules are separated by commas, parameters follow "=", e.g. "min=8"
+  - parameters may be quoted with ' or ", e.g. "match='^[a-z]+(,[a-z]+)*$'"
+  - outside quotes, a backslash escapes the next character, e.g. "match=^a\,b$"
+  - "|" separates alternatives, the field is valid if any of them passes, e.g. "email|url"
+
+Supported rules:
+
+  - required, omitempty, email, min, max, alpha, numeric, alphanumeric, phone, has_special
+  - match taking a regular expression, url taking the accepted schemes (url=https)
+  - creditcard taking the accepted brands (creditcard=visa mastercard), cvv the brand of the card (cvv=amex)
+  - date taking an optional layout (date=RFC3339, date=02.01.2006)
+  - before, after taking an RFC 3339 or YYYY-MM-DD date, between taking two (between=2024-01-01 2024-12-31)
+  - future, past, and within_last taking a duration (within_last=24h)
+  - ip, ipv4, ipv6, cidr, public_ip, private_ip, loopback
+  - ip_in_range taking CIDR prefixes (ip_in_range=10.0.0.0/8 fd00::/8)
+  - safe_outbound_url rejecting urls that point to internal addresses, see SafeOutboundURL()
+  - eq_field, ne_field, gt_field, gte_field, lt_field, lte_field taking the other field (eq_field=password)
+  - required_if, required_unless, excluded_if taking a field and a value (required_if=country US)
+  - required_with, required_without taking field names (required_with=street city)
+  - the rules registered with RegisterRule(), their parameters separated by spaces (tenant=acme beta)
+
+Example:
+
+	field, err := validator.ParseRules("email", cfg.Rules["email"])
+	if err != nil {
+	    return err
+	}
+	schema := validator.NewSchema(field)
+*/
+func ParseRules(name string, rules string) (*SchemaField, error) {
+	return parseRules(&SchemaField{name: name}, rules, isRegisteredRule)
+}
+
+/*
+This function parses a rule string into a new field of the schema, see ParseRules(), the rules
+may also use the rules registered with Schema.RegisterRule()
+
+returns: *Schema, a copy of the schema with the field appended, and a *ParseError for an invalid rule string
+
+Example:
+
+	schema := validator.NewSchema().RegisterRule("tenant", tenantRule)
+	schema, err := schema.ParseRules("tenant", "required,tenant=acme")
+*/
+func (s *Schema) ParseRules(name string, rules string) (*Schema, error) {
+	f, err := parseRules(&SchemaField{name: name}, rules, s.knowsRule)
+	if err != nil {
+		return nil, err
+	}
+	schema := *s
+	schema.fields = append(s.fields[:len(s.fields):len(s.fields)], f)
+	return &schema, nil
+}
+
+/*
+This function is ParseRules() panicking on an invalid rule string, for rules known at compile time
+
+returns: *SchemaField
+*/
+func MustParseRules(name string, rules string) *SchemaField {
+	f, err := ParseRules(name, rules)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+/*
+This function applies the rules of a SchemaField, e.g. parsed by ParseRules(), to the current field
+
+returns: *Validator
+
+Example:
+
+	rules := validator.MustParseRules("", "omitempty,min=8,has_special")
+	validator := NewValidator("password", data.Password).Apply(rules)
+*/
+func (v *Validator) Apply(rules *SchemaField) *Validator {
+	return rules.applyRules(v)
+}
+
+// parseRules appends the rules of a rule string to f, known reports whether a name that is not
+// a built-in rule names a registered one
+func parseRules(f *SchemaField, rules string, known func(name string) bool) (*SchemaField, error) {
+	groups, err := tokenizeRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// optional must be set before any other rule runs, wherever it appears
+	for _, group := range groups {
+		if len(group) == 1 && group[0].name == "omitempty" {
+			f = f.NotRequired().ZeroIsEmpty()
+		}
+	}
+
+	for _, group := range groups {
+		if len(group) == 1 {
+			if f, err = applyRuleToken(f, group[0], rules, known); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		alternatives := make([]*SchemaField, len(group))
+		for i, token := range group {
+			switch token.name {
+			case "required", "omitempty", "required_if", "required_unless", "required_with", "required_without", "excluded_if":
+				return nil, &ParseError{Rules: rules, Pos: token.pos, Msg: fmt.Sprintf("rule %q cannot be used as an alternative", token.name)}
+			}
+			if alternatives[i], err = applyRuleToken(Elem(), token, rules, known); err != nil {
+				return nil, err
+			}
+		}
+		f = f.anyOf(alternatives)
+	}
+	return f, nil
+}
+
+// tokenizeRules splits a rule string into comma separated groups of "|" separated alternatives
+func tokenizeRules(rules string) ([][]ruleToken, error) {
+	var groups [][]ruleToken
+	var group []ruleToken
+	i := 0
+
+	for {
+		token, next, err := scanRuleToken(rules, i)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, token)
+		i = next
+
+		if i < len(rules) && rules[i] == '|' {
+			i++
+			continue
+		}
+		// a lone empty rule, e.g. a trailing comma, is ignored
+		if len(group) > 1 || group[0].name != "" || group[0].hasParam {
+			for _, token := range group {
+				if token.name == "" {
+					return nil, &ParseError{Rules: rules, Pos: token.pos, Msg: "missing rule name"}
+				}
+			}
+			groups = append(groups, group)
+		}
+		group = nil
+		if i >= len(rules) {
+			return groups, nil
+		}
+		i++ // skip ','
+	}
+}
+
+// scanRuleToken reads the rule starting at offset i, up to the next unescaped ',' or '|' or the end of rules
+func scanRuleToken(rules string, i int) (ruleToken, int, error) {
+	for i < len(rules) && rules[i] == ' ' {
+		i++
+	}
+	token := ruleToken{pos: i}
+	start := i
+	for i < len(rules) && rules[i] != '=' && rules[i] != ',' && rules[i] != '|' {
+		i++
+	}
+	token.name = strings.TrimSpace(rules[start:i])
+	if i == len(rules) || rules[i] != '=' {
+		return token, i, nil
+	}
+
+	i++ // skip '='
+	for i < len(rules) && rules[i] == ' ' {
+		i++
+	}
+	token.hasParam = true
+	token.paramPos = i
+
+	var param strings.Builder
+	if i < len(rules) && (rules[i] == '\'' || rules[i] == '"') {
+		quote := rules[i]
+		token.quoted = true
+		for i++; ; i++ {
+			if i >= len(rules) {
+				return token, i, &ParseError{Rules: rules, Pos: token.paramPos, Msg: "unterminated quoted parameter"}
+			}
+			if rules[i] == '\\' && i+1 < len(rules) && (rules[i+1] == quote || rules[i+1] == '\\') {
+				i++
+			} else if rules[i] == quote {
+				break
+			}
+			param.WriteByte(rules[i])
+		}
+		i++ // skip the closing quote
+		for i < len(rules) && rules[i] == ' ' {
+			i++
+		}
+		if i < len(rules) && rules[i] != ',' && rules[i] != '|' {
+			return token, i, &ParseError{Rules: rules, Pos: i, Msg: "unexpected character after quoted parameter"}
+		}
+		token.param = param.String()
+		return token, i, nil
+	}
+
+	for ; i < len(rules) && rules[i] != ',' && rules[i] != '|'; i++ {
+		if rules[i] == '\\' {
+			if i+1 == len(rules) {
+				return token, i, &ParseError{Rules: rules, Pos: i, Msg: "dangling escape"}
+			}
+			i++
+		}
+		param.WriteByte(rules[i])
+	}
+	token.param = strings.TrimRight(param.String(), " ")
+	return token, i, nil
+}
+
+// dateLayoutNames are the layouts the date rule accepts by name, e.g. date=RFC3339
+var dateLayoutNames = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"DateOnly":    time.DateOnly,
+	"DateTime":    time.DateTime,
+}
+
+// applyRuleToken adds the rule described by token to f
+func applyRuleToken(f *SchemaField, token ruleToken, rules string, known func(name string) bool) (*SchemaField, error) {
+	paramError := func(expected string) error {
+		msg := fmt.Sprintf("rule %q expects %s", token.name, expected)
+		if token.hasParam {
+			msg += fmt.Sprintf(", got %q", token.param)
+			return &ParseError{Rules: rules, Pos: token.paramPos, Msg: msg}
+		}
+		return &ParseError{Rules: rules, Pos: token.pos, Msg: msg}
+	}
+	intParam := func() (int, error) {
+		n, err := strconv.Atoi(token.param)
+		if err != nil {
+			return 0, paramError("an integer parameter")
+		}
+		return n, nil
+	}
+	fieldParam := func() (string, error) {
+		if strings.TrimSpace(token.param) == "" {
+			return "", paramError("a field name parameter")
+		}
+		return strings.TrimSpace(token.param), nil
+	}
+	fieldValueParam := func() (string, interface{}, error) {
+		fieldName, value, ok := strings.Cut(strings.TrimSpace(token.param), " ")
+		if !ok || fieldName == "" {
+			return "", nil, paramError(`a "field value" parameter`)
+		}
+		return fieldName, strings.TrimSpace(value), nil
+	}
+
+	switch token.name {
+	case "required", "omitempty", "email", "alpha", "numeric", "alphanumeric", "phone", "ip", "ipv4", "ipv6", "cidr", "public_ip", "private_ip", "loopback", "safe_outbound_url", "has_special", "future", "past":
+		if token.hasParam {
+			return nil, &ParseError{Rules: rules, Pos: token.paramPos, Msg: fmt.Sprintf("rule %q takes no parameter", token.name)}
+		}
+	}
+
+	switch token.name {
+	case "required", "omitempty":
+		return f, nil
+	case "email":
+		return f.Email(), nil
+	case "url":
+		if !token.hasParam {
+			return f.Url(), nil
+		}
+		schemes := strings.Fields(token.param)
+		if len(schemes) == 0 {
+			return nil, paramError("url schemes such as https")
+		}
+		return f.Url(URLPolicy{Schemes: schemes}), nil
+	case "alpha":
+		return f.Alpha(), nil
+	case "numeric":
+		return f.Numeric(), nil
+	case "alphanumeric":
+		return f.AlphaNumeric(), nil
+	case "date":
+		if !token.hasParam {
+			return f.Date(), nil
+		}
+		if layout, ok := dateLayoutNames[token.param]; ok {
+			return f.Date(layout), nil
+		}
+		return f.Date(token.param), nil
+	case "phone":
+		return f.PhoneNumber(), nil
+	case "creditcard", "cvv":
+		var brands []CardBrand
+		for _, name := range strings.Fields(token.param) {
+			brand := CardBrand(strings.ToLower(name))
+			if !knownCardBrand(brand) {
+				return nil, paramError("card brands such as visa or amex")
+			}
+			brands = append(brands, brand)
+		}
+		if token.name == "creditcard" {
+			return f.CreditCard(brands...), nil
+		}
+		switch len(brands) {
+		case 0:
+			return f.CVV(""), nil
+		case 1:
+			return f.CVV(brands[0]), nil
+		}
+		return nil, paramError("a single card brand")
+	case "ip":
+		return f.IPAddress(), nil
+	case "ipv4":
+		return f.IPv4(), nil
+	case "ipv6":
+		return f.IPv6(), nil
+	case "cidr":
+		return f.CIDR(), nil
+	case "public_ip":
+		return f.PublicIP(), nil
+	case "private_ip":
+		return f.PrivateIP(), nil
+	case "loopback":
+		return f.Loopback(), nil
+	case "safe_outbound_url":
+		return f.SafeOutboundURL(), nil
+	case "ip_in_range":
+		var prefixes []netip.Prefix
+		for _, param := range strings.Fields(token.param) {
+			prefix, err := netip.ParsePrefix(param)
+			if err != nil {
+				return nil, paramError("CIDR prefixes such as 10.0.0.0/8")
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		if len(prefixes) == 0 {
+			return nil, paramError("CIDR prefixes such as 10.0.0.0/8")
+		}
+		return f.IPInRange(prefixes...), nil
+	case "has_special":
+		return f.HasSpecialChar(), nil
+	case "future":
+		return f.Future(), nil
+	case "past":
+		return f.Past(), nil
+	case "before", "after":
+		t, ok := parseTime(token.param, defaultDateLayouts)
+		if !ok {
+			return nil, paramError("an RFC 3339 or YYYY-MM-DD date parameter")
+		}
+		if token.name == "before" {
+			return f.Before(t), nil
+		}
+		return f.After(t), nil
+	case "between":
+		start, end, _ := strings.Cut(strings.TrimSpace(token.param), " ")
+		startTime, ok := parseTime(start, defaultDateLayouts)
+		endTime, ok2 := parseTime(strings.TrimSpace(end), defaultDateLayouts)
+		if !ok || !ok2 {
+			return nil, paramError(`a "start end" pair of RFC 3339 or YYYY-MM-DD dates`)
+		}
+		return f.Between(startTime, endTime), nil
+	case "within_last":
+		d, err := time.ParseDuration(token.param)
+		if err != nil || d <= 0 {
+			return nil, paramError("a duration parameter such as 24h")
+		}
+		return f.WithinLast(d), nil
+	case "min", "max":
+		n, err := intParam()
+		if err != nil {
+			return nil, err
+		}
+		if token.name == "min" {
+			return f.Min(n), nil
+		}
+		return f.Max(n), nil
+	case "match":
+		if token.param == "" {
+			return nil, paramError("a regular expression parameter")
+		}
+		pattern, err := regexp.Compile(token.param)
+		if err != nil {
+			return nil, &ParseError{Rules: rules, Pos: token.paramPos, Msg: fmt.Sprintf("rule %q has an invalid regular expression: %v", token.name, err)}
+		}
+		return f.Match(pattern), nil
+	case "eq_field", "ne_field", "gt_field", "gte_field", "lt_field", "lte_field":
+		fieldName, err := fieldParam()
+		if err != nil {
+			return nil, err
+		}
+		switch token.name {
+		case "eq_field":
+			return f.EqField(fieldName), nil
+		case "ne_field":
+			return f.NeField(fieldName), nil
+		case "gt_field":
+			return f.GtField(fieldName), nil
+		case "gte_field":
+			return f.GteField(fieldName), nil
+		case "lt_field":
+			return f.LtField(fieldName), nil
+		}
+		return f.LteField(fieldName), nil
+	case "required_if", "required_unless", "excluded_if":
+		fieldName, value, err := fieldValueParam()
+		if err != nil {
+			return nil, err
+		}
+		switch token.name {
+		case "required_if":
+			return f.RequiredIf(fieldName, value), nil
+		case "required_unless":
+			return f.RequiredUnless(fieldName, value), nil
+		}
+		return f.ExcludedIf(fieldName, value), nil
+	case "required_with", "required_without":
+		if _, err := fieldParam(); err != nil {
+			return nil, err
+		}
+		if token.name == "required_with" {
+			return f.RequiredWith(strings.Fields(token.param)...), nil
+		}
+		return f.RequiredWithout(strings.Fields(token.param)...), nil
+	}
+
+	if !known(token.name) {
+		return nil, &ParseError{Rules: rules, Pos: token.pos, Msg: fmt.Sprintf("unknown rule %q", token.name)}
+	}
+	params := strings.Fields(token.param)
+	if token.quoted {
+		params = []string{token.param}
+	}
+	return f.Rule(token.name, params...), nil
+}
+
+// anyOf passes if any of the alternatives passes, reporting the errors of the first one otherwise
+func (f *SchemaField) anyOf(alternatives []*SchemaField) *SchemaField {
+	args := make([]interface{}, len(alternatives))
+	for i, alternative := range alternatives {
+		args[i] = alternative
+	}
+	return f.with(schemaRule{code: "any_of", args: args, apply: func(v *Validator) *Validator {
+		return v.anyOf(alternatives)
+	}})
+}
+
+func (v *Validator) anyOf(alternatives []*SchemaField) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	var first []ValidationError
+	// CustomCtx() checks of the alternatives that passed their synchronous rules, in order
+	var pending [][]asyncField
+	for i, alternative := range alternatives {
+		errs, async := v.attempt(alternative)
+		if len(errs) == 0 {
+			if len(async) == 0 {
+				return v
+			}
+			pending = append(pending, async)
+			continue
+		}
+		if i == 0 {
+			first = errs
+		}
+	}
+	if len(pending) == 0 {
+		for _, err := range first {
+			v.recordError(err)
+		}
+		v.foundErr = true
+		return v
+	}
+
+	// the field passes if the checks of any pending alternative pass when ValidateCtx() runs them
+	return v.CustomCtx(func(ctx context.Context, value interface{}) error {
+		var failed []ValidationError
+		for _, fields := range pending {
+			errs, completed := runFields(ctx, fields)
+			if !completed {
+				// reported unchecked by ValidateCtx, an interrupted alternative neither passes nor fails
+				return ctx.Err()
+			}
+			if len(errs) == 0 {
+				return nil
+			}
+			if failed == nil {
+				failed = errs
+			}
+		}
+		if first != nil {
+			failed = first
+		}
+		return failed[0]
+	})
+}
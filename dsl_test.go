@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    string
+		value    interface{}
+		expected bool
+	}{
+		{"valid email", "required,email,max=30", "test@example.com", true},
+		{"too long", "required,email,max=10", "test@example.com", false},
+		{"optional empty", "omitempty,min=8,has_special", "", true},
+		{"optional short", "omitempty,min=8,has_special", "abc", false},
+		{"spaces", " min = 3 , alpha ", "abc", true},
+		{"trailing comma", "min=3,", "abc", true},
+		{"quoted match", `match='^[a-z]+(,[a-z]+)*$'`, "a,b,c", true},
+		{"quoted match fails", `match="^[a-z]+(,[a-z]+)*$"`, "a,,b", false},
+		{"escaped comma", `match=^a\,b$`, "a,b", true},
+		{"escaped pipe", `match=^(a\|b)$`, "b", true},
+		{"quote escape", `match='^it\'s$'`, "it's", true},
+		{"alternative first", "email|url", "test@example.com", true},
+		{"alternative second", "email|url", "https://example.com", true},
+		{"alternative none", "email|url", "nothing", false},
+		{"alternative with params", "max=3|min=10", "abcdefghijk", true},
+		{"registered rule", "sku", "ABC-1234", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := ParseRules("field", tt.rules)
+			if err != nil {
+				t.Fatalf("ParseRules(%q) = %v", tt.rules, err)
+			}
+			errs := NewSchema(field).Validate(map[string]interface{}{"field": tt.value})
+			if (errs == nil) != tt.expected {
+				t.Errorf("rules %q for %v = %v; want %v", tt.rules, tt.value, errs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := []struct {
+		rules string
+		pos   int
+		msg   string
+	}{
+		{"required,emial", 9, `unknown rule "emial"`},
+		{"min=abc", 4, `rule "min" expects an integer parameter, got "abc"`},
+		{"email,max", 6, `rule "max" expects an integer parameter`},
+		{"email=3", 6, `rule "email" takes no parameter`},
+		{"match='abc", 6, "unterminated quoted parameter"},
+		{"match='abc'x", 11, "unexpected character after quoted parameter"},
+		{`match=abc\`, 9, "dangling escape"},
+		{"email||url", 6, "missing rule name"},
+		{"=3", 0, "missing rule name"},
+		{"required|email", 0, `rule "required" cannot be used as an alternative`},
+		{"eq_field=", 9, `rule "eq_field" expects a field name parameter, got ""`},
+		{"required_if=country", 12, `rule "required_if" expects a "field value" parameter, got "country"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			_, err := ParseRules("field", tt.rules)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("ParseRules(%q) = %v; want a *ParseError", tt.rules, err)
+			}
+			if parseErr.Pos != tt.pos || parseErr.Msg != tt.msg {
+				t.Errorf("ParseRules(%q) = %d %q; want %d %q", tt.rules, parseErr.Pos, parseErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+
+	_, err := ParseRules("field", "match='('")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Pos != 6 {
+		t.Errorf("ParseRules() with an invalid pattern = %v", err)
+	}
+	if err.Error() == "" || parseErr.Rules != "match='('" {
+		t.Errorf("ParseError = %+v", parseErr)
+	}
+}
+
+func TestApply(t *testing.T) {
+	rules := MustParseRules("", "omitempty,min=8,has_special")
+
+	v := NewValidator("password", "secret").Apply(rules)
+	v.NextField("recovery", "").Apply(rules)
+	errs := v.GetError()
+	if len(errs) != 1 || errs[0].Field != "password" || errs[0].Code != CodeMin {
+		t.Errorf("Apply() = %v", errs)
+	}
+
+	errs = NewValidator("contact", "nothing").Apply(MustParseRules("", "email|phone")).GetError()
+	if len(errs) != 1 || errs[0].Code != CodeEmail || errs[0].Field != "contact" {
+		t.Errorf("alternatives should report the error of the first one, got %v", errs)
+	}
+}
+
+func TestMustParseRulesPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseRules() should panic on an invalid rule string")
+		}
+	}()
+	MustParseRules("field", "emial")
+}
+
+func TestTagAlternatives(t *testing.T) {
+	type contact struct {
+		Contact string `json:"contact" validate:"email|phone"`
+		Slug    string `json:"slug" validate:"match='^[a-z]+(-[a-z]+)*$'"`
+	}
+	if errs := ValidateStruct(contact{Contact: "+12345678901", Slug: "hello-world"}); errs != nil {
+		t.Errorf("tag alternatives = %v", errs)
+	}
+	errs := ValidateStruct(contact{Contact: "nothing", Slug: "Hello"})
+	if len(errs) != 2 || errs[0].Code != CodeEmail || errs[1].Code != CodeMatch {
+		t.Errorf("tag alternatives = %v", errs)
+	}
+}
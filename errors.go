@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// Error codes reported in ValidationError.Code, one per rule
+const (
+	CodeRequired       = "required"
+	CodeEmail          = "email"
+	CodeMin            = "min"
+	CodeMax            = "max"
+	CodeUrl            = "url"
+	CodeAlpha          = "alpha"
+	CodeNumeric        = "numeric"
+	CodeAlphaNumeric   = "alphanumeric"
+	CodeDate           = "date"
+	CodeMatch          = "match"
+	CodePhoneNumber    = "phone"
+	CodeCreditCard     = "creditcard"
+	CodeIPAddress      = "ip"
+	CodeHasSpecialChar = "has_special"
+)
+
+/*
+ErrValidation is matched by every ValidationError, so callers can check
+
+	errors.Is(err, validator.ErrValidation)
+
+without knowing which rule failed.
+*/
+var ErrValidation = errors.New("validation failed")
+
+/*
+ValidationError describes a single failed rule
+
+- Field: name of the field, the full dotted path for nested fields, e.g. "items[3].sku"
+
+- Path: segments of the field path, e.g. ["items", "3", "sku"]
+
+- Code: machine readable rule code, one of the Code* constants
+
+- Message: human readable message
+
+- Params: parameters the rule was called with, e.g. {"min": 5} for Min(5)
+
+- Value: the offending value
+*/
+type ValidationError struct {
+	Field   string
+	Path    []string
+	Code    string
+	Message string
+	Params  map[string]interface{}
+	Value   interface{}
+}
+
+/*
+This function returns the field path as a JSON Pointer (RFC 6901), e.g. "/items/3/sku"
+
+returns: string
+*/
+func (e ValidationError) Pointer() string {
+	path := e.Path
+	if path == nil && e.Field != "" {
+		path = []string{e.Field}
+	}
+
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(segment))
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + " " + e.Message
+}
+
+/*
+Is reports whether target matches this error
+
+  - ErrValidation always matches
+  - a ValidationError target matches when its Code and Field are equal,
+    empty Code or Field on the target act as wildcards
+
+Example:
+
+	errors.Is(err, validator.ValidationError{Field: "email", Code: validator.CodeRequired})
+*/
+func (e ValidationError) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+
+	var t ValidationError
+	switch target := target.(type) {
+	case ValidationError:
+		t = target
+	case *ValidationError:
+		if target == nil {
+			return false
+		}
+		t = *target
+	default:
+		return false
+	}
+	return (t.Code == "" || t.Code == e.Code) && (t.Field == "" || t.Field == e.Field)
+}
+
+/*
+ValidationErrors is the list of errors returned by GetError()
+
+It implements error and Unwrap() []error, so errors.Is and errors.As see every ValidationError.
+
+Note: Return it as an error only when it is non-empty, a nil ValidationErrors stored in an error interface is not nil.
+*/
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+/*
+This function returns the errors reported for the given field
+
+- fieldName: name of the field
+
+returns: ValidationErrors, nil when the field has no errors
+*/
+func (e ValidationErrors) Field(fieldName string) ValidationErrors {
+	var errs ValidationErrors
+	for _, err := range e {
+		if err.Field == fieldName {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
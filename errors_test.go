@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		validate func(*Validator)
+		code     string
+	}{
+		{"required", nil, func(v *Validator) { v.Email() }, CodeRequired},
+		{"email", "invalid-email", func(v *Validator) { v.Email() }, CodeEmail},
+		{"min", "te", func(v *Validator) { v.Min(3) }, CodeMin},
+		{"max", 42, func(v *Validator) { v.Max(10) }, CodeMax},
+		{"url", "invalid-url", func(v *Validator) { v.Url() }, CodeUrl},
+		{"ip", "300.300.0.0", func(v *Validator) { v.IPAddress() }, CodeIPAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("field", tt.value)
+			tt.validate(v)
+			errs := v.GetError()
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %v", errs)
+			}
+			if errs[0].Code != tt.code {
+				t.Errorf("Code = %q; want %q", errs[0].Code, tt.code)
+			}
+			if errs[0].Value != tt.value {
+				t.Errorf("Value = %v; want %v", errs[0].Value, tt.value)
+			}
+		})
+	}
+}
+
+func TestValidationErrorParams(t *testing.T) {
+	errs := NewValidator("field", "te").Min(3).GetError()
+	if errs[0].Params["min"] != 3 {
+		t.Errorf("Params[min] = %v; want 3", errs[0].Params["min"])
+	}
+}
+
+func TestValidationErrorsStandardErrors(t *testing.T) {
+	v := NewValidator("email", "invalid-email").Email()
+	v.NextField("username", nil).Min(5)
+
+	var err error = v.GetError()
+	if err.Error() != "email must be a valid email; username Field is Required" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("errors.Is(err, ErrValidation) should be true")
+	}
+	if !errors.Is(err, ValidationError{Field: "username", Code: CodeRequired}) {
+		t.Errorf("errors.Is() should match on field and code")
+	}
+	if !errors.Is(err, &ValidationError{Code: CodeEmail}) {
+		t.Errorf("errors.Is() should match on code alone")
+	}
+	if errors.Is(err, ValidationError{Field: "email", Code: CodeRequired}) {
+		t.Errorf("errors.Is() should not match a different code")
+	}
+
+	var target ValidationError
+	if !errors.As(err, &target) || target.Field != "email" {
+		t.Errorf("errors.As() should find the first ValidationError, got %v", target)
+	}
+
+	if got := v.GetError().Field("username"); len(got) != 1 || got[0].Code != CodeRequired {
+		t.Errorf("Field() = %v", got)
+	}
+}
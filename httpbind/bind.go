@@ -0,0 +1,278 @@
+/*
+Package httpbind decodes and validates JSON request bodies for net/http handlers
+
+Bind() decodes the body of a request into a value and validates it, with the `validate` tags of a
+struct or with a Schema. Middleware() does the same before a handler runs, answering invalid requests
+itself: 415 for a wrong Content-Type, 413 for a body over the size limit, 400 for malformed JSON and
+422 Unprocessable Entity, with every field error, for a body failing validation.
+
+Adapters for other frameworks use Decode(), which only needs a context, the Content-Type and the body, and
+Render(), which returns the status and the JSON document of an error.
+
+Example:
+
+	func createUser(w http.ResponseWriter, r *http.Request) {
+	    user, err := httpbind.Bind[userData](r, nil)
+	    if err != nil {
+	        httpbind.WriteError(w, err)
+	        return
+	    }
+	    // user is valid
+	}
+*/
+package httpbind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+// DefaultMaxBodyBytes is the size limit of a body when Binder.MaxBodyBytes is 0
+const DefaultMaxBodyBytes = 1 << 20
+
+var (
+	// ErrUnsupportedMediaType is wrapped by the Error of a body whose Content-Type is not accepted
+	ErrUnsupportedMediaType = errors.New("httpbind: unsupported media type")
+	// ErrBodyTooLarge is wrapped by the Error of a body over the size limit
+	ErrBodyTooLarge = errors.New("httpbind: request body too large")
+	// ErrInvalidJSON is wrapped by the Error of an empty or malformed JSON body
+	ErrInvalidJSON = errors.New("httpbind: invalid JSON body")
+	// ErrSchemaRequired is wrapped by the Error of a value that is not a struct and comes without a schema
+	ErrSchemaRequired = errors.New("httpbind: a schema is required to validate a value that is not a struct")
+)
+
+/*
+Error is returned by Bind() and Decode(), Status being the HTTP status to answer with
+
+Errors lists the field errors of a body failing validation, Status is then 422 and Err is Errors,
+so errors.Is(err, validator.ErrValidation) holds.
+*/
+type Error struct {
+	Status int
+	Errors validator.ValidationErrors
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+/*
+Binder configures how bodies are decoded and how errors are answered, the zero value is ready to use
+*/
+type Binder struct {
+	// MaxBodyBytes limits the size of a body, DefaultMaxBodyBytes when 0, no limit when negative
+	MaxBodyBytes int64
+
+	// ContentTypes are the accepted media types, application/json and any +json type when empty
+	ContentTypes []string
+
+	// OnError answers a request Middleware() rejects, WriteError() when nil
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// DefaultBinder is the Binder used by Bind() and by Middleware() when given a nil Binder
+var DefaultBinder = &Binder{}
+
+/*
+This function decodes and validates the JSON body of a request with DefaultBinder, see BindWith()
+
+returns: T, and an *Error when the body is rejected
+*/
+func Bind[T any](r *http.Request, schema *validator.Schema) (T, error) {
+	return BindWith[T](DefaultBinder, r, schema)
+}
+
+/*
+This function decodes and validates the JSON body of a request
+
+- b: the Binder, DefaultBinder when nil
+
+- r: the request, its body is consumed
+
+- schema: validates the decoded value, nil to validate a struct with its `validate` tags
+
+returns: T, and an *Error when the body is rejected, with status 500 wrapping ErrSchemaRequired when T is
+not a struct or pointer to struct and schema is nil
+
+Required fields are judged on the keys of the payload, see validator.ValidateJSON(). Context-aware rules,
+e.g. CustomCtx() and SafeOutboundURL(), are bounded by the context of the request.
+*/
+func BindWith[T any](b *Binder, r *http.Request, schema *validator.Schema) (T, error) {
+	var body io.Reader = http.NoBody
+	if r.Body != nil {
+		body = r.Body
+	}
+	return Decode[T](r.Context(), b, r.Header.Get("Content-Type"), body, schema)
+}
+
+/*
+This function decodes and validates a JSON body, for adapters of frameworks that do not expose an *http.Request
+
+- ctx: bounds the context-aware rules, e.g. the context of the request
+
+- b: the Binder, DefaultBinder when nil
+
+- contentType: the Content-Type header of the request
+
+- body: the body of the request
+
+- schema: validates the decoded value, nil to validate a struct with its `validate` tags
+
+returns: T, and an *Error when the body is rejected, with status 503 wrapping ctx.Err() when ctx ended
+before the body was validated
+
+Example:
+
+	app.Post("/users", func(c fiber.Ctx) error {
+	    user, err := httpbind.Decode[userData](c.Context(), nil, c.Get("Content-Type"), bytes.NewReader(c.Body()), nil)
+	    if err != nil {
+	        status, doc := httpbind.Render(err)
+	        return c.Status(status).JSON(doc)
+	    }
+	    ...
+	})
+*/
+func Decode[T any](ctx context.Context, b *Binder, contentType string, body io.Reader, schema *validator.Schema) (T, error) {
+	var dst T
+	if b == nil {
+		b = DefaultBinder
+	}
+	// without a schema the `validate` tags of a struct are the only rules there are
+	if rt := reflect.TypeOf(&dst).Elem(); schema == nil && derefType(rt).Kind() != reflect.Struct {
+		return dst, &Error{Status: http.StatusInternalServerError, Err: fmt.Errorf("%w, got %s", ErrSchemaRequired, rt)}
+	}
+	if err := b.checkContentType(contentType); err != nil {
+		return dst, err
+	}
+	data, err := b.read(body)
+	if err != nil {
+		return dst, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return dst, &Error{Status: http.StatusBadRequest, Err: fmt.Errorf("%w: empty body", ErrInvalidJSON)}
+	}
+
+	var errs validator.ValidationErrors
+	if schema == nil {
+		errs, err = validator.ValidateJSONCtx(ctx, data, &dst)
+	} else {
+		errs, err = schema.ValidateJSONCtx(ctx, data, &dst)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return dst, &Error{Status: http.StatusServiceUnavailable, Err: fmt.Errorf("httpbind: validation interrupted: %w", err)}
+	}
+	if err != nil {
+		return dst, decodeError(err)
+	}
+	if errs != nil {
+		return dst, &Error{Status: http.StatusUnprocessableEntity, Errors: errs, Err: errs}
+	}
+	return dst, nil
+}
+
+func (b *Binder) checkContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil {
+		if len(b.ContentTypes) == 0 && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+			return nil
+		}
+		for _, accepted := range b.ContentTypes {
+			if strings.EqualFold(accepted, mediaType) {
+				return nil
+			}
+		}
+	}
+	return &Error{Status: http.StatusUnsupportedMediaType, Err: fmt.Errorf("%w %q", ErrUnsupportedMediaType, contentType)}
+}
+
+func (b *Binder) read(body io.Reader) ([]byte, error) {
+	limit := b.MaxBodyBytes
+	if limit == 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	if limit < 0 {
+		data, err := io.ReadAll(body)
+		return data, readError(err)
+	}
+
+	// one byte more than the limit tells a body at the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, readError(err)
+	}
+	if int64(len(data)) > limit {
+		return nil, &Error{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)}
+	}
+	return data, nil
+}
+
+func readError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// set by http.MaxBytesReader when the server limits the body itself
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return &Error{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytes.Limit)}
+	}
+	return &Error{Status: http.StatusBadRequest, Err: fmt.Errorf("httpbind: reading body: %w", err)}
+}
+
+// decodeError turns a decoding error into an *Error, a value of the wrong type being a field error
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		expected := jsonTypeName(typeErr.Type)
+		errs := validator.ValidationErrors{{
+			Field:   typeErr.Field,
+			Path:    strings.Split(typeErr.Field, "."),
+			Code:    validator.CodeTypeMismatch,
+			Message: "must be of type " + expected + ", got " + typeErr.Value,
+			Params:  map[string]interface{}{"expected": expected, "actual": typeErr.Value},
+		}}
+		return &Error{Status: http.StatusUnprocessableEntity, Errors: errs, Err: errs}
+	}
+	return &Error{Status: http.StatusBadRequest, Err: fmt.Errorf("%w: %v", ErrInvalidJSON, err)}
+}
+
+// jsonTypeName names the JSON type a Go type is decoded from
+func jsonTypeName(rt reflect.Type) string {
+	switch rt = derefType(rt); rt.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return rt.String()
+}
+
+func derefType(rt reflect.Type) reflect.Type {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt
+}
@@ -0,0 +1,168 @@
+package httpbind
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+type signup struct {
+	Email    string `json:"email" validate:"email,max=30"`
+	Password string `json:"password" validate:"min=8"`
+	Age      int    `json:"age" validate:"min=18"`
+}
+
+func newRequest(contentType string, body string) *http.Request {
+	r := httptest.NewRequest("POST", "/signup", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestBind(t *testing.T) {
+	user, err := Bind[signup](newRequest("application/json", `{"email": "ann@example.com", "password": "s3cret!!", "age": 30}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (signup{Email: "ann@example.com", Password: "s3cret!!", Age: 30}); user != want {
+		t.Errorf("Bind() = %+v; want %+v", user, want)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		target      error
+	}{
+		{"invalid fields", "application/json", `{"email": "ann", "password": "short", "age": 30}`, 422, validator.ErrValidation},
+		{"missing field", "application/json; charset=utf-8", `{"email": "ann@example.com", "password": "s3cret!!"}`, 422, validator.ErrValidation},
+		{"wrong type", "application/json", `{"email": "ann@example.com", "password": "s3cret!!", "age": "thirty"}`, 422, validator.ErrValidation},
+		{"malformed JSON", "application/json", `{"email": `, 400, ErrInvalidJSON},
+		{"empty body", "application/json", ``, 400, ErrInvalidJSON},
+		{"missing content type", "", `{}`, 415, ErrUnsupportedMediaType},
+		{"wrong content type", "text/plain", `{}`, 415, ErrUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind[signup](newRequest(tt.contentType, tt.body), nil)
+			var bindErr *Error
+			if !errors.As(err, &bindErr) || bindErr.Status != tt.status {
+				t.Fatalf("Bind() error = %v; want status %d", err, tt.status)
+			}
+			if !errors.Is(err, tt.target) {
+				t.Errorf("Bind() error = %v; want %v", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestBindFieldErrors(t *testing.T) {
+	_, err := Bind[signup](newRequest("application/json", `{"email": "ann", "password": "short", "age": 30}`), nil)
+	var bindErr *Error
+	errors.As(err, &bindErr)
+	codes := map[string]string{}
+	for _, e := range bindErr.Errors {
+		codes[e.Field] = e.Code
+	}
+	if want := map[string]string{"email": validator.CodeEmail, "password": validator.CodeMin}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("Bind() errors = %v; want %v", codes, want)
+	}
+
+	_, err = Bind[signup](newRequest("application/json", `{"age": "thirty"}`), nil)
+	errors.As(err, &bindErr)
+	if len(bindErr.Errors) != 1 || bindErr.Errors[0].Field != "age" || bindErr.Errors[0].Message != "must be of type integer, got string" {
+		t.Errorf("Bind() errors = %#v", bindErr.Errors)
+	}
+}
+
+func TestBindSchema(t *testing.T) {
+	schema := validator.NewSchema(
+		validator.Field("name").Min(2),
+		validator.Field("tags").NotRequired().Max(2),
+	)
+	data, err := Bind[map[string]interface{}](newRequest("application/problem+json", `{"name": "Go", "tags": ["a"]}`), schema)
+	if err != nil || data["name"] != "Go" {
+		t.Errorf("Bind() = %v, %v", data, err)
+	}
+
+	_, err = Bind[map[string]interface{}](newRequest("application/json", `{"name": "G"}`), schema)
+	if !errors.Is(err, validator.ValidationError{Field: "name", Code: validator.CodeMin}) {
+		t.Errorf("Bind() error = %v", err)
+	}
+}
+
+func TestBinderLimits(t *testing.T) {
+	body := `{"email": "ann@example.com", "password": "s3cret!!", "age": 30}`
+
+	b := &Binder{MaxBodyBytes: int64(len(body))}
+	if _, err := BindWith[signup](b, newRequest("application/json", body), nil); err != nil {
+		t.Errorf("body at the limit: BindWith() error = %v", err)
+	}
+	b.MaxBodyBytes--
+	_, err := BindWith[signup](b, newRequest("application/json", body), nil)
+	var bindErr *Error
+	if !errors.As(err, &bindErr) || bindErr.Status != http.StatusRequestEntityTooLarge || !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("body over the limit: BindWith() error = %v", err)
+	}
+
+	// a limit set by the server is reported the same way
+	r := newRequest("application/json", body)
+	r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 10)
+	if _, err := Bind[signup](r, nil); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("MaxBytesReader: Bind() error = %v", err)
+	}
+
+	b = &Binder{ContentTypes: []string{"text/json"}}
+	if _, err := BindWith[signup](b, newRequest("text/json", body), nil); err != nil {
+		t.Errorf("accepted content type: BindWith() error = %v", err)
+	}
+	if _, err := BindWith[signup](b, newRequest("application/json", body), nil); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("content type not listed: BindWith() error = %v", err)
+	}
+}
+
+func TestBindContext(t *testing.T) {
+	hang := func(ctx context.Context, value interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	schema := validator.NewSchema(validator.Field("email").Email().CustomCtx(hang))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := newRequest("application/json", `{"email": "ann@example.com"}`).WithContext(ctx)
+	cancel()
+	_, err := Bind[map[string]interface{}](r, schema)
+	var bindErr *Error
+	if !errors.As(err, &bindErr) || bindErr.Status != http.StatusServiceUnavailable || !errors.Is(err, context.Canceled) {
+		t.Errorf("Bind() with a cancelled request = %v; want a 503 wrapping context.Canceled", err)
+	}
+}
+
+func TestBindWithoutSchema(t *testing.T) {
+	_, err := Bind[map[string]interface{}](newRequest("application/json", `{"email": "ann"}`), nil)
+	var bindErr *Error
+	if !errors.As(err, &bindErr) || bindErr.Status != http.StatusInternalServerError || !errors.Is(err, ErrSchemaRequired) {
+		t.Errorf("Bind() of a map without a schema = %v; want a 500 wrapping ErrSchemaRequired", err)
+	}
+	if _, err := Bind[*signup](newRequest("application/json", `{"email": "ann@example.com", "password": "s3cret!!", "age": 30}`), nil); err != nil {
+		t.Errorf("Bind() of a struct pointer = %v", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	user, err := Decode[signup](context.Background(), nil, "application/json", strings.NewReader(`{"email": "ann@example.com", "password": "s3cret!!", "age": 30}`), nil)
+	if err != nil || user.Age != 30 {
+		t.Errorf("Decode() = %+v, %v", user, err)
+	}
+}
@@ -0,0 +1,127 @@
+package httpbind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+/*
+Response is the JSON document written for a rejected request
+
+Example:
+
+	{
+	  "message": "validation failed",
+	  "errors": [
+	    {"field": "email", "code": "email", "message": "must be a valid email"},
+	    {"field": "password", "code": "min", "message": "must be greater than or equal to 8", "params": {"min": 8}}
+	  ]
+	}
+*/
+type Response struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a validator.ValidationError in a Response
+type FieldError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// contextKey is the context key of the value decoded by Middleware[T], one per type
+type contextKey[T any] struct{}
+
+/*
+This function returns a middleware decoding and validating the JSON body of every request into a T, see BindWith()
+
+- b: the Binder, DefaultBinder when nil
+
+- schema: validates the decoded value, nil to validate a struct with its `validate` tags
+
+returns: func(http.Handler) http.Handler
+
+Rejected requests are answered by b.OnError, the next handler reads the value with FromContext().
+
+Example:
+
+	mux.Handle("POST /users", httpbind.Middleware[userData](nil, nil)(http.HandlerFunc(createUser)))
+
+	func createUser(w http.ResponseWriter, r *http.Request) {
+	    user, _ := httpbind.FromContext[userData](r.Context())
+	    ...
+	}
+*/
+func Middleware[T any](b *Binder, schema *validator.Schema) func(http.Handler) http.Handler {
+	if b == nil {
+		b = DefaultBinder
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value, err := BindWith[T](b, r, schema)
+			if err != nil {
+				if b.OnError != nil {
+					b.OnError(w, r, err)
+				} else {
+					WriteError(w, err)
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, value)))
+		})
+	}
+}
+
+/*
+This function returns the value decoded by Middleware[T]
+
+returns: T, and false when no Middleware[T] ran for the request
+*/
+func FromContext[T any](ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(contextKey[T]{}).(T)
+	return value, ok
+}
+
+/*
+This function returns the HTTP status and the JSON document answering an error of Bind(), for adapters
+
+- err: an *Error or validator.ValidationErrors, any other error is answered with 500 Internal Server Error
+
+returns: int, Response
+*/
+func Render(err error) (int, Response) {
+	var bindErr *Error
+	if !errors.As(err, &bindErr) {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return http.StatusInternalServerError, Response{Message: strings.ToLower(http.StatusText(http.StatusInternalServerError))}
+		}
+		bindErr = &Error{Status: http.StatusUnprocessableEntity, Errors: errs, Err: errs}
+	}
+
+	if bindErr.Errors == nil {
+		return bindErr.Status, Response{Message: strings.TrimPrefix(bindErr.Err.Error(), "httpbind: ")}
+	}
+	response := Response{Message: "validation failed", Errors: make([]FieldError, len(bindErr.Errors))}
+	for i, e := range bindErr.Errors {
+		response.Errors[i] = FieldError{Field: e.Field, Code: e.Code, Message: e.Message, Params: e.Params}
+	}
+	return bindErr.Status, response
+}
+
+/*
+This function answers an error of Bind() with its status and a JSON Response, see Render()
+*/
+func WriteError(w http.ResponseWriter, err error) {
+	status, response := Render(err)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
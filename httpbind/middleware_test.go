@@ -0,0 +1,86 @@
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+func TestMiddleware(t *testing.T) {
+	var got signup
+	handler := Middleware[signup](nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		if got, ok = FromContext[signup](r.Context()); !ok {
+			t.Errorf("FromContext() found no value")
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("application/json", `{"email": "ann@example.com", "password": "s3cret!!", "age": 30}`))
+	if w.Code != http.StatusCreated || got.Email != "ann@example.com" {
+		t.Errorf("valid body: status %d, value %+v", w.Code, got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("application/json", `{"email": "ann", "password": "s3cret!!"}`))
+	if w.Code != http.StatusUnprocessableEntity || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatalf("invalid body: status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	want := Response{Message: "validation failed", Errors: []FieldError{
+		{Field: "email", Code: validator.CodeEmail, Message: "must be a valid email"},
+		{Field: "age", Code: validator.CodeRequired, Message: "Field is Required"},
+	}}
+	if !reflect.DeepEqual(response, want) {
+		t.Errorf("invalid body: response %+v; want %+v", response, want)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("text/plain", `{}`))
+	if w.Code != http.StatusUnsupportedMediaType || w.Body.String() != "{\"message\":\"unsupported media type \\\"text/plain\\\"\"}\n" {
+		t.Errorf("wrong content type: status %d, body %s", w.Code, w.Body)
+	}
+}
+
+func TestMiddlewareOnError(t *testing.T) {
+	var rejected error
+	b := &Binder{OnError: func(w http.ResponseWriter, r *http.Request, err error) {
+		rejected = err
+		w.WriteHeader(http.StatusTeapot)
+	}}
+	handler := Middleware[signup](b, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("the handler should not run for an invalid body")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("application/json", `{`))
+	if w.Code != http.StatusTeapot || !errors.Is(rejected, ErrInvalidJSON) {
+		t.Errorf("OnError: status %d, error %v", w.Code, rejected)
+	}
+}
+
+func TestRender(t *testing.T) {
+	errs := validator.NewValidator("name", "x").Min(3).GetError()
+	status, response := Render(errs)
+	if status != http.StatusUnprocessableEntity || len(response.Errors) != 1 || response.Errors[0].Params["min"] != 3 {
+		t.Errorf("Render(ValidationErrors) = %d, %+v", status, response)
+	}
+
+	status, response = Render(errors.New("boom"))
+	if status != http.StatusInternalServerError || response.Message != "internal server error" {
+		t.Errorf("Render(error) = %d, %+v", status, response)
+	}
+
+	if _, ok := FromContext[signup](httptest.NewRequest("GET", "/", nil).Context()); ok {
+		t.Errorf("FromContext() should find no value without Middleware")
+	}
+}
@@ -0,0 +1,331 @@
+package validator
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Translator turns a ValidationError into a localized message
+
+returns: the message and true, or false to keep the default English message
+*/
+type Translator interface {
+	Translate(err ValidationError) (string, bool)
+}
+
+/*
+Catalog is a Translator backed by messages keyed by rule code
+
+Messages may use the rule Params as placeholders, e.g. "must be at least {min} characters long",
+and {field} for the field name. A "<code>.string" or "<code>.number" entry takes precedence
+over "<code>" when the offending value is a string or a number.
+
+A message is either a plain string or a plural form selected by a numeric parameter:
+
+	{
+	    "required": "ist ein Pflichtfeld",
+	    "min.string": {
+	        "count": "min",
+	        "one": "muss mindestens {min} Zeichen lang sein",
+	        "other": "muss mindestens {min} Zeichen lang sein"
+	    }
+	}
+
+Plural categories follow the CLDR names (zero, one, two, few, many, other), "other" is the fallback.
+*/
+type Catalog struct {
+	locale   string
+	messages map[string]catalogMessage
+}
+
+type catalogMessage struct {
+	count string
+	forms map[string]string
+}
+
+//go:embed locales/*.json
+var embeddedCatalogs embed.FS
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Translator{}
+)
+
+func init() {
+	entries, err := embeddedCatalogs.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		locale := strings.TrimSuffix(entry.Name(), ".json")
+		data, err := embeddedCatalogs.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		catalog, err := NewCatalog(locale, data)
+		if err != nil {
+			panic(err)
+		}
+		locales[locale] = catalog
+	}
+}
+
+/*
+This function parses a JSON message catalog
+
+- locale: language tag of the catalog, e.g. "de" or "pt-BR", used to pick the plural rules
+
+- data: JSON object mapping rule codes to messages
+
+- returns: *Catalog, error
+*/
+func NewCatalog(locale string, data []byte) (*Catalog, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("validator: catalog %q: %w", locale, err)
+	}
+
+	catalog := &Catalog{locale: locale, messages: make(map[string]catalogMessage, len(raw))}
+	for key, value := range raw {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			catalog.messages[key] = catalogMessage{forms: map[string]string{"other": text}}
+			continue
+		}
+
+		var forms map[string]string
+		if err := json.Unmarshal(value, &forms); err != nil {
+			return nil, fmt.Errorf("validator: catalog %q: message %q must be a string or an object of plural forms", locale, key)
+		}
+		message := catalogMessage{count: forms["count"], forms: forms}
+		delete(message.forms, "count")
+		if _, ok := message.forms["other"]; !ok {
+			return nil, fmt.Errorf("validator: catalog %q: message %q has no \"other\" plural form", locale, key)
+		}
+		catalog.messages[key] = message
+	}
+	return catalog, nil
+}
+
+func (c *Catalog) Translate(err ValidationError) (string, bool) {
+	message, ok := c.messages[err.Code+"."+valueKind(err.Value)]
+	if !ok {
+		message, ok = c.messages[err.Code]
+	}
+	if !ok {
+		return "", false
+	}
+
+	text := message.forms["other"]
+	if message.count != "" {
+		if n, ok := toFloat64(err.Params[message.count]); ok {
+			if form, ok := message.forms[pluralCategory(c.locale, n)]; ok {
+				text = form
+			}
+		}
+	}
+	return formatMessage(text, err), true
+}
+
+/*
+This function registers a Translator for a locale, replacing any embedded catalog
+
+- locale: language tag, e.g. "de" or "pt-BR"
+
+- translator: the Translator used by Locale(locale)
+*/
+func RegisterLocale(locale string, translator Translator) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(locale)] = translator
+}
+
+/*
+This function returns the Translator registered for a locale
+
+"de-AT" falls back to "de" when no "de-AT" translator exists.
+
+returns: Translator, nil when the locale is unknown
+*/
+func LocaleTranslator(locale string) Translator {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if translator, ok := locales[locale]; ok {
+		return translator
+	}
+	base, _, _ := strings.Cut(locale, "-")
+	return locales[base]
+}
+
+/*
+This function selects the language of the error messages
+
+- locale: language tag, e.g. "de", unknown locales keep the default English messages
+
+returns: *Validator
+*/
+func (v *Validator) Locale(locale string) *Validator {
+	return v.WithTranslator(LocaleTranslator(locale))
+}
+
+/*
+This function translates error messages with a custom Translator
+
+Errors already collected are translated as well.
+
+returns: *Validator
+*/
+func (v *Validator) WithTranslator(translator Translator) *Validator {
+	v.translator = translator
+	if translator != nil {
+		for i := range v.errors {
+			v.errors[i] = translate(translator, v.errors[i])
+		}
+	}
+	return v
+}
+
+/*
+This function returns a copy of the errors with messages translated
+
+- translator: e.g. LocaleTranslator("de")
+
+returns: ValidationErrors
+*/
+func (e ValidationErrors) Translate(translator Translator) ValidationErrors {
+	if e == nil || translator == nil {
+		return e
+	}
+	errs := make(ValidationErrors, len(e))
+	for i, err := range e {
+		errs[i] = translate(translator, err)
+	}
+	return errs
+}
+
+func translate(translator Translator, err ValidationError) ValidationError {
+	if message, ok := translator.Translate(err); ok {
+		err.Message = message
+	}
+	return err
+}
+
+// formatMessage replaces {field} and {param} placeholders
+func formatMessage(text string, err ValidationError) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	replacements := []string{"{field}", err.Field}
+	for key, value := range err.Params {
+		replacements = append(replacements, "{"+key+"}", formatParam(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+func formatParam(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	if d, ok := value.(time.Duration); ok {
+		return formatDuration(d)
+	}
+	return fmt.Sprint(value)
+}
+
+// valueKind classifies a value for "<code>.<kind>" catalog keys
+func valueKind(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	}
+	if _, ok := toFloat64(value); ok {
+		return "number"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Slice, reflect.Array:
+		return "slice"
+	}
+	return ""
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// pluralCategory returns the CLDR plural category of n for the language of locale
+func pluralCategory(locale string, n float64) string {
+	if n != math.Trunc(n) {
+		return "other"
+	}
+	i := int64(math.Abs(n))
+
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	switch language {
+	case "fr", "pt":
+		if i == 0 || i == 1 {
+			return "one"
+		}
+	case "ru", "uk", "be":
+		switch {
+		case i%10 == 1 && i%100 != 11:
+			return "one"
+		case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "pl":
+		switch {
+		case i == 1:
+			return "one"
+		case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "ja", "zh", "ko", "vi", "th", "id":
+		return "other"
+	default:
+		if i == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
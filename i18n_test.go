@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	v := NewValidator("email", "invalid-email").Locale("de").Email()
+	v.NextField("username", nil).Min(5)
+
+	errs := v.GetError()
+	if errs[0].Message != "muss eine gültige E-Mail-Adresse sein" {
+		t.Errorf("Locale(de) email message = %q", errs[0].Message)
+	}
+	if errs[1].Message != "ist ein Pflichtfeld" {
+		t.Errorf("Locale(de) required message = %q", errs[1].Message)
+	}
+	if errs[0].Code != CodeEmail {
+		t.Errorf("Locale() should not change the error code")
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	errs := NewValidator("email", "invalid-email").Locale("fr-CA").Email().GetError()
+	if errs[0].Message != "doit être une adresse e-mail valide" {
+		t.Errorf("Locale(fr-CA) should fall back to fr, got %q", errs[0].Message)
+	}
+
+	errs = NewValidator("email", "invalid-email").Locale("xx").Email().GetError()
+	if errs[0].Message != "must be a valid email" {
+		t.Errorf("unknown locale should keep the default message, got %q", errs[0].Message)
+	}
+}
+
+func TestLocalePlural(t *testing.T) {
+	tests := []struct {
+		locale string
+		value  interface{}
+		min    int
+		want   string
+	}{
+		{"fr", nil, 1, "est obligatoire"},
+		{"fr", "ab", 3, "doit contenir au moins 3 caractères"},
+		{"es", "ab", 3, "debe tener al menos 3 caracteres"},
+		{"ru", "a", 2, "должно содержать минимум 2 символа"},
+		{"ru", "a", 5, "должно содержать минимум 5 символов"},
+		{"ru", "a", 21, "должно содержать минимум 21 символ"},
+		{"de", 3, 5, "muss größer oder gleich 5 sein"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			errs := NewValidator("field", tt.value).Locale(tt.locale).Min(tt.min).GetError()
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %v", errs)
+			}
+			if errs[0].Message != tt.want {
+				t.Errorf("Message = %q; want %q", errs[0].Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      float64
+		want   string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"fr", 0, "one"},
+		{"ru", 3, "few"},
+		{"ru", 12, "many"},
+		{"ru", 101, "one"},
+		{"pl", 22, "few"},
+		{"ja", 1, "other"},
+		{"en", 1.5, "other"},
+	}
+
+	for _, tt := range tests {
+		if got := pluralCategory(tt.locale, tt.n); got != tt.want {
+			t.Errorf("pluralCategory(%s, %v) = %s; want %s", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCustomTranslator(t *testing.T) {
+	catalog, err := NewCatalog("en", []byte(`{"min": {"count": "min", "one": "{field} needs {min} item", "other": "{field} needs {min} items"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterLocale("en-x-test", catalog)
+
+	errs := NewValidator("tags", 0.5).Locale("en-x-test").Min(1).GetError()
+	if errs[0].Message != "tags needs 1 item" {
+		t.Errorf("Message = %q", errs[0].Message)
+	}
+
+	translated := NewValidator("tags", 0.5).Min(3).GetError().Translate(catalog)
+	if translated[0].Message != "tags needs 3 items" {
+		t.Errorf("Translate() message = %q", translated[0].Message)
+	}
+
+	if _, err := NewCatalog("en", []byte(`{"min": {"one": "x"}}`)); err == nil {
+		t.Errorf("NewCatalog() should reject plural messages without an other form")
+	}
+}
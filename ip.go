@@ -0,0 +1,312 @@
+package validator
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Error codes of the ip address rules
+const (
+	CodeIPv4      = "ipv4"
+	CodeIPv6      = "ipv6"
+	CodeCIDR      = "cidr"
+	CodeIPInRange = "ip_in_range"
+	CodePublicIP  = "public_ip"
+	CodePrivateIP = "private_ip"
+	CodeLoopback  = "loopback"
+)
+
+// specialPurposePrefixes are the special-purpose ranges of RFC 6890 that netip.Addr.IsGlobalUnicast()
+// does not rule out, e.g. shared address space or documentation
+var specialPurposePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// parseIP reads an ip address from a string, a netip.Addr or a net.IP, isIP is false for other types
+func parseIP(data interface{}) (addr netip.Addr, valid bool, isIP bool) {
+	switch data := data.(type) {
+	case string:
+		addr, err := netip.ParseAddr(data)
+		return addr, err == nil, true
+	case netip.Addr:
+		return data, data.IsValid(), true
+	case net.IP:
+		addr, ok := netip.AddrFromSlice(data)
+		// net.ParseIP() stores IPv4 addresses in their 16 byte form
+		return addr.Unmap(), ok, true
+	}
+	return netip.Addr{}, false, false
+}
+
+// isPublicIP reports whether addr is routable on the public internet
+func isPublicIP(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range specialPurposePrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipFormat reports code when the field is not an ip address for which valid returns true
+func (v *Validator) ipFormat(code string, message string, valid func(addr netip.Addr) bool) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	addr, ok, isIP := parseIP(v.data)
+	if !isIP {
+		v.typeMismatch("string")
+		return v
+	}
+	if !ok || !valid(addr) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    code,
+			Message: message,
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+// checkIP reports err when the field is an ip address for which valid returns false,
+// and an ip error when it is no ip address at all
+func (v *Validator) checkIP(valid func(addr netip.Addr) bool, err ValidationError) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	addr, ok, isIP := parseIP(v.data)
+	if !isIP {
+		v.typeMismatch("string")
+		return v
+	}
+	if !ok {
+		err = ValidationError{Code: CodeIPAddress, Message: "must be a valid ip address"}
+	} else if valid(addr.Unmap()) {
+		return v
+	}
+	err.Field = v.fieldName
+	err.Value = v.data
+	v.appendError(err)
+	return v
+}
+
+/*
+This function checks if the field is a valid IPv4 or IPv6 address
+
+returns: *Validator
+
+Strings, netip.Addr and net.IP values are accepted, IPv6 addresses may carry a zone, e.g. "fe80::1%eth0".
+*/
+func (v *Validator) IPAddress() *Validator {
+	return v.ipFormat(CodeIPAddress, "must be a valid ip address", func(addr netip.Addr) bool { return true })
+}
+
+/*
+This function checks if the field is a valid IPv4 address, e.g. "192.168.1.1"
+
+returns: *Validator
+*/
+func (v *Validator) IPv4() *Validator {
+	return v.ipFormat(CodeIPv4, "must be a valid IPv4 address", netip.Addr.Is4)
+}
+
+/*
+This function checks if the field is a valid IPv6 address, e.g. "2001:db8::1" or "::ffff:192.168.1.1"
+
+returns: *Validator
+*/
+func (v *Validator) IPv6() *Validator {
+	return v.ipFormat(CodeIPv6, "must be a valid IPv6 address", netip.Addr.Is6)
+}
+
+/*
+This function checks if the field is a valid CIDR prefix, e.g. "10.0.0.0/8" or "2001:db8::/32"
+
+returns: *Validator
+
+Strings, netip.Prefix and *net.IPNet values are accepted. Host bits may be set, e.g. "10.1.2.3/8".
+*/
+func (v *Validator) CIDR() *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	valid := false
+	switch data := v.data.(type) {
+	case string:
+		_, err := netip.ParsePrefix(data)
+		valid = err == nil
+	case netip.Prefix:
+		valid = data.IsValid()
+	case net.IPNet:
+		_, err := netip.ParsePrefix(data.String())
+		valid = err == nil
+	default:
+		v.typeMismatch("string")
+		return v
+	}
+	if !valid {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeCIDR,
+			Message: "must be a valid CIDR prefix",
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+/*
+This function checks if the field is an ip address in one of the prefixes
+
+- prefixes: the allowed ranges, e.g. netip.MustParsePrefix("10.0.0.0/8")
+
+returns: *Validator
+
+IPv4-mapped IPv6 addresses are matched as IPv4 addresses.
+
+Example:
+
+	internal := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+	validator := NewValidator("clientIP", r.RemoteAddr).IPInRange(internal...)
+*/
+func (v *Validator) IPInRange(prefixes ...netip.Prefix) *Validator {
+	ranges := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		ranges[i] = prefix.String()
+	}
+	return v.checkIP(func(addr netip.Addr) bool {
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr.WithZone("")) {
+				return true
+			}
+		}
+		return false
+	}, ValidationError{
+		Code:    CodeIPInRange,
+		Message: "must be in the allowed ranges " + strings.Join(ranges, ", "),
+		Params:  map[string]interface{}{"ranges": strings.Join(ranges, ", ")},
+	})
+}
+
+/*
+This function checks if the field is an ip address routable on the public internet
+
+returns: *Validator
+
+Private, loopback, link-local, multicast, unspecified and the special-purpose ranges of RFC 6890,
+e.g. 100.64.0.0/10 or 2001:db8::/32, are not public.
+*/
+func (v *Validator) PublicIP() *Validator {
+	return v.checkIP(isPublicIP, ValidationError{
+		Code:    CodePublicIP,
+		Message: "must be a public ip address",
+	})
+}
+
+/*
+This function checks if the field is a private ip address, i.e. in 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16 or fc00::/7
+
+returns: *Validator
+*/
+func (v *Validator) PrivateIP() *Validator {
+	return v.checkIP(netip.Addr.IsPrivate, ValidationError{
+		Code:    CodePrivateIP,
+		Message: "must be a private ip address",
+	})
+}
+
+/*
+This function checks if the field is a loopback address, i.e. in 127.0.0.0/8 or ::1
+
+returns: *Validator
+*/
+func (v *Validator) Loopback() *Validator {
+	return v.checkIP(netip.Addr.IsLoopback, ValidationError{
+		Code:    CodeLoopback,
+		Message: "must be a loopback address",
+	})
+}
+
+/*
+This function checks if the field is a valid IPv4 address, see Validator.IPv4()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) IPv4() *SchemaField {
+	return f.with(schemaRule{code: CodeIPv4, apply: (*Validator).IPv4})
+}
+
+/*
+This function checks if the field is a valid IPv6 address, see Validator.IPv6()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) IPv6() *SchemaField {
+	return f.with(schemaRule{code: CodeIPv6, apply: (*Validator).IPv6})
+}
+
+/*
+This function checks if the field is a valid CIDR prefix, see Validator.CIDR()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) CIDR() *SchemaField {
+	return f.with(schemaRule{code: CodeCIDR, apply: (*Validator).CIDR})
+}
+
+/*
+This function checks if the field is an ip address in one of the prefixes, see Validator.IPInRange()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) IPInRange(prefixes ...netip.Prefix) *SchemaField {
+	args := make([]interface{}, len(prefixes))
+	for i, prefix := range prefixes {
+		args[i] = prefix.String()
+	}
+	return f.with(schemaRule{code: CodeIPInRange, args: args, apply: func(v *Validator) *Validator {
+		return v.IPInRange(prefixes...)
+	}})
+}
+
+/*
+This function checks if the field is a public ip address, see Validator.PublicIP()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) PublicIP() *SchemaField {
+	return f.with(schemaRule{code: CodePublicIP, apply: (*Validator).PublicIP})
+}
+
+/*
+This function checks if the field is a private ip address, see Validator.PrivateIP()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) PrivateIP() *SchemaField {
+	return f.with(schemaRule{code: CodePrivateIP, apply: (*Validator).PrivateIP})
+}
+
+/*
+This function checks if the field is a loopback address, see Validator.Loopback()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Loopback() *SchemaField {
+	return f.with(schemaRule{code: CodeLoopback, apply: (*Validator).Loopback})
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestIPFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		rule  func(v *Validator) *Validator
+		want  []string
+	}{
+		{"ipv4", "192.168.1.1", (*Validator).IPAddress, []string{}},
+		{"ipv6", "2001:db8::1", (*Validator).IPAddress, []string{}},
+		{"ipv6 zone", "fe80::1%eth0", (*Validator).IPAddress, []string{}},
+		{"out of range", "300.300.0.0", (*Validator).IPAddress, []string{"ip:ip"}},
+		{"leading zero", "192.168.01.1", (*Validator).IPAddress, []string{"ip:ip"}},
+		{"netip.Addr", netip.MustParseAddr("::1"), (*Validator).IPAddress, []string{}},
+		{"zero netip.Addr", netip.Addr{}, (*Validator).IPAddress, []string{"ip:ip"}},
+		{"net.IP", net.ParseIP("10.0.0.1"), (*Validator).IPAddress, []string{}},
+		{"IPv4 only", "10.0.0.1", (*Validator).IPv4, []string{}},
+		{"IPv4 rejects IPv6", "::1", (*Validator).IPv4, []string{"ip:ipv4"}},
+		{"IPv4 net.IP", net.ParseIP("10.0.0.1"), (*Validator).IPv4, []string{}},
+		{"IPv6 only", "::ffff:10.0.0.1", (*Validator).IPv6, []string{}},
+		{"IPv6 rejects IPv4", "10.0.0.1", (*Validator).IPv6, []string{"ip:ipv6"}},
+		{"IPv6 garbage", "fe80::zz", (*Validator).IPv6, []string{"ip:ipv6"}},
+		{"cidr", "10.0.0.0/8", (*Validator).CIDR, []string{}},
+		{"cidr ipv6", "2001:db8::/32", (*Validator).CIDR, []string{}},
+		{"cidr no bits", "10.0.0.0", (*Validator).CIDR, []string{"ip:cidr"}},
+		{"cidr too many bits", "10.0.0.0/33", (*Validator).CIDR, []string{"ip:cidr"}},
+		{"cidr netip.Prefix", netip.MustParsePrefix("fd00::/8"), (*Validator).CIDR, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.rule(NewValidator("ip", tt.value)).GetError()
+			if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetError() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPClasses(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		rule  func(v *Validator) *Validator
+		want  []string
+	}{
+		{"8.8.8.8", (*Validator).PublicIP, []string{}},
+		{"2606:4700::1111", (*Validator).PublicIP, []string{}},
+		{"10.1.2.3", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"100.64.0.1", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"169.254.169.254", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"192.0.2.1", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"::ffff:127.0.0.1", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"fc00::1", (*Validator).PublicIP, []string{"ip:public_ip"}},
+		{"not an ip", (*Validator).PublicIP, []string{"ip:ip"}},
+		{"172.16.0.1", (*Validator).PrivateIP, []string{}},
+		{"fd12::1", (*Validator).PrivateIP, []string{}},
+		{net.ParseIP("192.168.0.1"), (*Validator).PrivateIP, []string{}},
+		{"8.8.8.8", (*Validator).PrivateIP, []string{"ip:private_ip"}},
+		{"127.0.0.2", (*Validator).Loopback, []string{}},
+		{"::1", (*Validator).Loopback, []string{}},
+		{"10.0.0.1", (*Validator).Loopback, []string{"ip:loopback"}},
+	}
+
+	for _, tt := range tests {
+		errs := tt.rule(NewValidator("ip", tt.value)).GetError()
+		if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: GetError() = %v; want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIPInRange(t *testing.T) {
+	internal := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+	tests := []struct {
+		value interface{}
+		want  []string
+	}{
+		{"10.20.30.40", []string{}},
+		{"::ffff:10.0.0.1", []string{}},
+		{"fd00::1%eth0", []string{}},
+		{net.ParseIP("10.0.0.1"), []string{}},
+		{"192.168.0.1", []string{"ip:ip_in_range"}},
+		{"fe80::1", []string{"ip:ip_in_range"}},
+	}
+
+	for _, tt := range tests {
+		errs := NewValidator("ip", tt.value).IPInRange(internal...).GetError()
+		if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("IPInRange() on %v = %v; want %v", tt.value, got, tt.want)
+		}
+	}
+
+	errs := NewValidator("ip", "192.168.0.1").IPInRange(internal...).GetError()
+	if errs[0].Params["ranges"] != "10.0.0.0/8, fd00::/8" {
+		t.Errorf("ip_in_range params = %v", errs[0].Params)
+	}
+}
+
+func TestIPRules(t *testing.T) {
+	type client struct {
+		Addr    string `json:"addr" validate:"ip,public_ip"`
+		Gateway string `json:"gateway" validate:"ip_in_range=10.0.0.0/8 192.168.0.0/16"`
+		Subnet  string `json:"subnet" validate:"cidr"`
+	}
+	errs := ValidateStruct(client{Addr: "2001:db8::1", Gateway: "172.16.0.1", Subnet: "10.0.0.0/8"})
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"addr:public_ip", "gateway:ip_in_range"}) {
+		t.Errorf("ValidateStruct() = %v", got)
+	}
+
+	for _, rules := range []string{"ip_in_range", "ip_in_range=10.0.0.0", "ipv4=strict"} {
+		if _, err := ParseRules("field", rules); err == nil {
+			t.Errorf("ParseRules(%q) should fail", rules)
+		}
+	}
+}
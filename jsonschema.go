@@ -0,0 +1,356 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the $schema of the documents produced by Schema.JSONSchema()
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaExtension is the keyword listing the rules JSON Schema cannot express, e.g. custom and cross-field rules
+const JSONSchemaExtension = "x-validator-rules"
+
+/*
+This function describes the schema as a JSON Schema 2020-12 document, ready for json.Marshal
+
+returns: map[string]interface{}
+
+Rules are mapped to their JSON Schema keywords: Min and Max to minLength/maxLength for strings,
+minimum/maximum for numbers and minItems/maxItems for arrays (both the string and number keywords
+when the type of the field is unknown), Email, Url, Date, IPv4 and IPv6 to format (IPAddress to anyOf both), Match and the
+character class rules to pattern, Nested to an object and Each to items. Fields are listed in
+required unless NotRequired() or a conditional rule applies.
+
+Url also adds a pattern of the accepted schemes, and the keywords of a field using NotRequired().ZeroIsEmpty()
+are wrapped in anyOf with the zero value of its type, e.g. anyOf: [{"const": ""}, {"minLength": 3}].
+
+Rules JSON Schema cannot express, i.e. registered, custom, cross-field and conditional rules
+and the host, credential, query, fragment and length restrictions of a URLPolicy, are listed
+under the "x-validator-rules" keyword of their field as {"rule": code, "params": [...]}.
+
+Note: Schemas built by StructSchema() know the Go type of their fields, nested structs are
+described once under $defs and referenced with $ref.
+
+Example:
+
+	doc, _ := json.MarshalIndent(validator.StructSchema(reflect.TypeOf(User{})).JSONSchema(), "", "  ")
+*/
+func (s *Schema) JSONSchema() map[string]interface{} {
+	e := &schemaExporter{defs: map[string]interface{}{}, names: map[reflect.Type]string{}}
+	doc := e.object(s)
+	doc["$schema"] = JSONSchemaDialect
+	if len(e.defs) > 0 {
+		doc["$defs"] = e.defs
+	}
+	return doc
+}
+
+// schemaExporter collects the $defs of the struct types met while exporting a schema
+type schemaExporter struct {
+	defs  map[string]interface{}
+	names map[reflect.Type]string
+}
+
+func (e *schemaExporter) object(s *Schema) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, f := range s.fields {
+		properties[f.name] = e.field(f)
+		if f.alwaysRequired() {
+			required = append(required, f.name)
+		}
+	}
+
+	doc := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return doc
+}
+
+// alwaysRequired reports whether a missing field is an error whatever the other fields are
+func (f *SchemaField) alwaysRequired() bool {
+	if f.optional {
+		return false
+	}
+	for _, r := range f.rules {
+		if r.presence {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *schemaExporter) field(f *SchemaField) map[string]interface{} {
+	doc := map[string]interface{}{}
+	kind := jsonType(f.typ)
+	if kind != "" {
+		doc["type"] = kind
+	}
+	if f.typ != nil && derefType(f.typ) == timeType {
+		doc["format"] = "date-time"
+	}
+
+	for _, r := range f.rules {
+		e.rule(doc, r, kind)
+	}
+	if f.optional && f.zeroIsEmpty && (f.typ == nil || derefType(f.typ) != timeType) {
+		return zeroOr(doc)
+	}
+	return doc
+}
+
+// zeroOr accepts the zero value of the type next to the keywords of doc, the rules skip a ZeroIsEmpty field left empty
+func zeroOr(doc map[string]interface{}) map[string]interface{} {
+	var zero map[string]interface{}
+	switch doc["type"] {
+	case "string":
+		zero = map[string]interface{}{"const": ""}
+	case "integer", "number":
+		zero = map[string]interface{}{"const": 0}
+	case "boolean":
+		zero = map[string]interface{}{"const": false}
+	case "array":
+		zero = map[string]interface{}{"const": []interface{}{}}
+	case nil:
+		zero = map[string]interface{}{"enum": []interface{}{"", 0, false, []interface{}{}, map[string]interface{}{}}}
+	default:
+		return doc
+	}
+
+	keywords := map[string]interface{}{}
+	for key, value := range doc {
+		if key != "type" && key != JSONSchemaExtension {
+			keywords[key] = value
+		}
+	}
+	if len(keywords) == 0 {
+		return doc
+	}
+	wrapped := map[string]interface{}{"anyOf": []interface{}{zero, keywords}}
+	if kind, ok := doc["type"]; ok {
+		wrapped["type"] = kind
+	}
+	if ext, ok := doc[JSONSchemaExtension]; ok {
+		wrapped[JSONSchemaExtension] = ext
+	}
+	return wrapped
+}
+
+func (e *schemaExporter) rule(doc map[string]interface{}, r schemaRule, kind string) {
+	switch r.code {
+	case CodeEmail:
+		stringKeyword(doc, "format", "email")
+	case CodeUrl:
+		stringKeyword(doc, "format", "uri")
+		if len(r.args) == 0 {
+			addKeyword(doc, "pattern", schemePattern(defaultUrlSchemes))
+		}
+		for _, arg := range r.args {
+			policy := arg.(URLPolicy)
+			schemes := policy.Schemes
+			if len(schemes) == 0 {
+				schemes = defaultUrlSchemes
+			}
+			addKeyword(doc, "pattern", schemePattern(schemes))
+			if policy.AllowedHosts != nil || policy.DeniedHosts != nil || policy.ForbidCredentials || policy.RequireQuery ||
+				policy.ForbidQuery || policy.RequireFragment || policy.ForbidFragment || policy.MaxLength > 0 {
+				extension(doc, map[string]interface{}{"rule": r.code, "params": []interface{}{policy}})
+			}
+		}
+	case CodeDate:
+		switch {
+		case len(r.args) == 0 || len(r.args) == 1 && r.args[0] == time.DateOnly:
+			stringKeyword(doc, "format", "date")
+		case len(r.args) == 1 && r.args[0] == time.RFC3339:
+			stringKeyword(doc, "format", "date-time")
+		default:
+			extension(doc, map[string]interface{}{"rule": r.code, "params": r.args})
+		}
+	case CodeIPAddress:
+		addKeyword(doc, "anyOf", []interface{}{
+			map[string]interface{}{"format": "ipv4"},
+			map[string]interface{}{"format": "ipv6"},
+		})
+	case CodeIPv4:
+		stringKeyword(doc, "format", "ipv4")
+	case CodeIPv6:
+		stringKeyword(doc, "format", "ipv6")
+	case CodeMatch:
+		stringKeyword(doc, "pattern", r.args[0].(*regexp.Regexp).String())
+	case CodeAlpha:
+		stringKeyword(doc, "pattern", alphaRegex.String())
+	case CodeNumeric:
+		stringKeyword(doc, "pattern", numericRegex.String())
+	case CodeAlphaNumeric:
+		stringKeyword(doc, "pattern", alphaNumericRegex.String())
+	case CodePhoneNumber:
+		stringKeyword(doc, "pattern", phoneRegex.String())
+	case CodeHasSpecialChar:
+		stringKeyword(doc, "pattern", specialCharRegex.String())
+	case CodeMin, CodeMax:
+		bound := "minimum"
+		length, items := "minLength", "minItems"
+		if r.code == CodeMax {
+			bound, length, items = "maximum", "maxLength", "maxItems"
+		}
+		switch kind {
+		case "string":
+			addKeyword(doc, length, r.args[0])
+		case "integer", "number":
+			addKeyword(doc, bound, r.args[0])
+		case "array":
+			addKeyword(doc, items, r.args[0])
+		case "":
+			// like the rule itself, bound the length of a string and the value of a number
+			addKeyword(doc, length, r.args[0])
+			addKeyword(doc, bound, r.args[0])
+		}
+	case "nested":
+		switch nested := r.args[0].(type) {
+		case *Schema:
+			for key, value := range e.object(nested) {
+				doc[key] = value
+			}
+		case reflect.Type:
+			doc["$ref"] = "#/$defs/" + e.define(nested)
+		}
+	case "each":
+		if _, ok := doc["type"]; !ok {
+			doc["type"] = "array"
+		}
+		doc["items"] = e.field(r.args[0].(*SchemaField))
+	case "any_of":
+		alternatives := make([]interface{}, len(r.args))
+		for i, alternative := range r.args {
+			alternatives[i] = e.field(alternative.(*SchemaField))
+		}
+		addKeyword(doc, "anyOf", alternatives)
+	case "type", "const", "enum", "minLength", "maxLength", "minItems", "maxItems",
+		"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+		// rules of schemas built by CompileJSONSchema() keep their keyword as code
+		addKeyword(doc, r.code, r.args[0])
+	case "one_of":
+		alternatives := make([]interface{}, len(r.args))
+		for i, alternative := range r.args {
+			alternatives[i] = e.field(alternative.(*SchemaField))
+		}
+		addKeyword(doc, "oneOf", alternatives)
+	case "all_of":
+		allOf, _ := doc["allOf"].([]interface{})
+		doc["allOf"] = append(allOf, e.field(r.args[0].(*SchemaField)))
+	case "transform":
+	case "when":
+		extension(doc, map[string]interface{}{"rule": r.code, "then": e.field(r.args[0].(*SchemaField))})
+	default:
+		ext := map[string]interface{}{"rule": r.code}
+		if len(r.args) > 0 {
+			ext["params"] = r.args
+		}
+		extension(doc, ext)
+	}
+}
+
+// define describes a struct type under $defs once, returning its name there
+func (e *schemaExporter) define(rt reflect.Type) string {
+	rt = derefType(rt)
+	if name, ok := e.names[rt]; ok {
+		return name
+	}
+
+	base := rt.Name()
+	if base == "" {
+		base = "object"
+	}
+	name := base
+	for i := 2; e.defs[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	// reserve the name first, recursive types refer to themselves
+	e.names[rt] = name
+	e.defs[name] = true
+	e.defs[name] = e.object(structTemplateOf(rt).schema)
+	return name
+}
+
+// schemePattern matches a url starting with one of the schemes, whatever their case.
+// JSON Schema patterns are ECMA-262 regular expressions without (?i), letters are spelled as [hH].
+func schemePattern(schemes []string) string {
+	var b strings.Builder
+	b.WriteString("^(")
+	for i, scheme := range schemes {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		for _, c := range strings.ToLower(scheme) {
+			if c >= 'a' && c <= 'z' {
+				b.WriteString("[" + string(c) + string(c-'a'+'A') + "]")
+			} else {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		}
+	}
+	b.WriteString("):")
+	return b.String()
+}
+
+// stringKeyword adds a keyword of a string rule, the field is a string unless its Go type says otherwise
+func stringKeyword(doc map[string]interface{}, key string, value interface{}) {
+	if _, ok := doc["type"]; !ok {
+		doc["type"] = "string"
+	}
+	addKeyword(doc, key, value)
+}
+
+// addKeyword sets a keyword, moving a second value of the same keyword to allOf
+func addKeyword(doc map[string]interface{}, key string, value interface{}) {
+	if _, ok := doc[key]; !ok {
+		doc[key] = value
+		return
+	}
+	allOf, _ := doc["allOf"].([]interface{})
+	doc["allOf"] = append(allOf, map[string]interface{}{key: value})
+}
+
+func extension(doc map[string]interface{}, rule map[string]interface{}) {
+	rules, _ := doc[JSONSchemaExtension].([]interface{})
+	doc[JSONSchemaExtension] = append(rules, rule)
+}
+
+// jsonType returns the JSON Schema type of a Go type, "" when unknown
+func jsonType(rt reflect.Type) string {
+	if rt == nil {
+		return ""
+	}
+	rt = derefType(rt)
+	if rt == timeType {
+		return "string"
+	}
+	switch rt.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
+
+func derefType(rt reflect.Type) reflect.Type {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt
+}
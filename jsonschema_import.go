@@ -0,0 +1,856 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Error codes of the JSON Schema keywords without an equivalent rule
+const (
+	CodeEnum         = "enum"
+	CodeConst        = "const"
+	CodeExclusiveMin = "exclusive_min"
+	CodeExclusiveMax = "exclusive_max"
+	CodeOneOf        = "one_of"
+)
+
+/*
+This function builds a Schema from a JSON Schema document, see CompileJSONSchema()
+
+- data: JSON Schema document
+
+returns: *Schema, and an error when the document is not valid JSON or not a supported JSON Schema
+*/
+func LoadJSONSchema(data []byte) (*Schema, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("validator: invalid JSON Schema: %w", err)
+	}
+	return CompileJSONSchema(doc)
+}
+
+/*
+This function builds a Schema from a decoded JSON Schema 2020-12 document, whose root describes an object
+
+- doc: the document, as decoded by encoding/json
+
+returns: *Schema, and an error pointing at the first malformed keyword
+
+Supported keywords: type, required, properties, items, enum, const, minLength, maxLength, minimum,
+maximum, exclusiveMinimum, exclusiveMaximum, minItems, maxItems, pattern, format (email, uri, date,
+date-time, ipv4 and ipv6), oneOf, anyOf, allOf and $ref to a local "#/..." location. Other keywords are
+annotations and are ignored, like unknown formats.
+
+Errors are reported with the usual codes and with the path of the value, see ValidationError.Pointer().
+
+Note: As everywhere in this package, null counts as missing: a required property set to null reports
+a required error, unless its type, enum or const allow null, e.g. "type": ["string", "null"].
+
+Example:
+
+	schema, err := validator.LoadJSONSchema(upstreamSchema)
+	if err != nil {
+	    return err
+	}
+	var payload map[string]interface{}
+	json.Unmarshal(body, &payload)
+	errs := schema.Validate(payload)
+*/
+func CompileJSONSchema(doc interface{}) (*Schema, error) {
+	return CompileJSONSchemaAt(doc, "")
+}
+
+/*
+This function builds a Schema from the object schema at a location of a larger document, e.g. an
+OpenAPI document, references being resolved against the whole document
+
+- doc: the document, as decoded by encoding/json
+
+- pointer: JSON Pointer of the object schema, e.g. "/components/schemas/User", "" for the root
+
+returns: *Schema, and an error pointing at the first malformed keyword
+*/
+func CompileJSONSchemaAt(doc interface{}, pointer string) (*Schema, error) {
+	c := &schemaCompiler{root: doc, refs: map[string]*SchemaField{}}
+	sub, ok := resolvePointer(doc, pointer)
+	if !ok {
+		return nil, c.errorf(pointer, "no schema at this location")
+	}
+	return c.object(sub, pointer)
+}
+
+/*
+This function builds a field from the schema at a location of a document, which may describe any
+type, e.g. the schema of a query parameter
+
+- name: name of the field
+
+- doc: the document, as decoded by encoding/json
+
+- pointer: JSON Pointer of the schema
+
+returns: *SchemaField, and an error pointing at the first malformed keyword
+*/
+func CompileJSONSchemaField(name string, doc interface{}, pointer string) (*SchemaField, error) {
+	c := &schemaCompiler{root: doc, refs: map[string]*SchemaField{}}
+	sub, ok := resolvePointer(doc, pointer)
+	if !ok {
+		return nil, c.errorf(pointer, "no schema at this location")
+	}
+	return c.rules(Field(name), sub, pointer)
+}
+
+// schemaCompiler compiles the subschemas of a JSON Schema document, pointer is the location of the current one
+type schemaCompiler struct {
+	root interface{}
+	refs map[string]*SchemaField
+}
+
+func (c *schemaCompiler) errorf(pointer string, format string, args ...interface{}) error {
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Errorf("validator: invalid JSON Schema at %s: %s", pointer, fmt.Sprintf(format, args...))
+}
+
+// object compiles an object schema into a Schema, merging the properties of allOf subschemas
+func (c *schemaCompiler) object(doc interface{}, pointer string) (*Schema, error) {
+	keywords, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, c.errorf(pointer, "expected an object schema")
+	}
+	if types, ok := keywords["type"]; ok && types != "object" {
+		return nil, c.errorf(pointer+"/type", "the root schema must describe an object")
+	}
+
+	var fields []*SchemaField
+	if err := c.properties(keywords, pointer, &fields); err != nil {
+		return nil, err
+	}
+	if allOf, ok := keywords["allOf"].([]interface{}); ok {
+		for i, sub := range allOf {
+			if subKeywords, ok := sub.(map[string]interface{}); ok {
+				if err := c.properties(subKeywords, pointer+"/allOf/"+strconv.Itoa(i), &fields); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return NewSchema(fields...), nil
+}
+
+func (c *schemaCompiler) properties(keywords map[string]interface{}, pointer string, fields *[]*SchemaField) error {
+	required := map[string]bool{}
+	if list, ok := keywords["required"]; ok {
+		names, ok := list.([]interface{})
+		if !ok {
+			return c.errorf(pointer+"/required", "expected an array of property names")
+		}
+		for _, name := range names {
+			name, ok := name.(string)
+			if !ok {
+				return c.errorf(pointer+"/required", "expected an array of property names")
+			}
+			required[name] = true
+		}
+	}
+
+	properties := map[string]interface{}{}
+	if value, ok := keywords["properties"]; ok {
+		if properties, ok = value.(map[string]interface{}); !ok {
+			return c.errorf(pointer+"/properties", "expected an object")
+		}
+	}
+	// properties are validated in a stable order, required ones without a schema included
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	for name := range required {
+		if _, ok := properties[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := Field(name)
+		if sub, ok := properties[name]; ok {
+			var err error
+			if f, err = c.rules(f, sub, pointer+"/properties/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+		if !required[name] {
+			f = f.NotRequired()
+		}
+		*fields = append(*fields, f)
+	}
+	return nil
+}
+
+// rules adds the assertions of the subschema doc to f
+func (c *schemaCompiler) rules(f *SchemaField, doc interface{}, pointer string) (*SchemaField, error) {
+	keywords, ok := doc.(map[string]interface{})
+	if !ok {
+		if doc == true {
+			return f, nil
+		}
+		return nil, c.errorf(pointer, "expected a schema object")
+	}
+
+	// keywords are applied in a fixed order so that the first error of a field is deterministic
+	for _, keyword := range []string{
+		"$ref", "type", "const", "enum", "minLength", "maxLength", "pattern", "format",
+		"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "minItems", "maxItems",
+		"properties", "required", "items", "allOf", "anyOf", "oneOf",
+	} {
+		value, ok := keywords[keyword]
+		if !ok {
+			continue
+		}
+		at := pointer + "/" + keyword
+
+		var err error
+		switch keyword {
+		case "$ref":
+			f, err = c.ref(f, value, at)
+		case "type":
+			f, err = c.typeRule(f, value, at)
+		case "const":
+			f = f.with(schemaRule{code: keyword, args: []interface{}{value}, apply: func(v *Validator) *Validator {
+				return v.jsonConst(value)
+			}})
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok {
+				return nil, c.errorf(at, "expected an array")
+			}
+			f = f.with(schemaRule{code: keyword, args: []interface{}{values}, apply: func(v *Validator) *Validator {
+				return v.jsonEnum(values)
+			}})
+		case "minLength", "maxLength", "minItems", "maxItems":
+			n, ok := jsonInt(value)
+			if !ok || n < 0 {
+				return nil, c.errorf(at, "expected a non-negative integer")
+			}
+			f = f.jsonLength(keyword, n)
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			bound, ok := jsonFloat(value)
+			if !ok {
+				return nil, c.errorf(at, "expected a number")
+			}
+			f = f.jsonBound(keyword, bound)
+		case "pattern":
+			text, ok := value.(string)
+			if !ok {
+				return nil, c.errorf(at, "expected a string")
+			}
+			pattern, err := regexp.Compile(text)
+			if err != nil {
+				return nil, c.errorf(at, "invalid pattern: %v", err)
+			}
+			f = f.jsonPattern(pattern)
+		case "format":
+			f = jsonFormat(f, value)
+		case "properties", "required":
+			if _, ok := keywords["properties"]; ok && keyword == "required" {
+				continue
+			}
+			object := map[string]interface{}{"properties": keywords["properties"], "required": keywords["required"]}
+			if object["properties"] == nil {
+				delete(object, "properties")
+			}
+			if object["required"] == nil {
+				delete(object, "required")
+			}
+			var nested *Schema
+			if nested, err = c.object(object, pointer); err == nil {
+				f = f.Nested(nested)
+			}
+		case "items":
+			var elem *SchemaField
+			if elem, err = c.rules(Elem(), value, at); err == nil {
+				f = f.Each(elem)
+			}
+		case "allOf", "anyOf", "oneOf":
+			var subs []*SchemaField
+			if subs, err = c.subschemas(value, at); err != nil {
+				return nil, err
+			}
+			switch keyword {
+			case "allOf":
+				for _, sub := range subs {
+					f = f.with(schemaRule{code: "all_of", args: []interface{}{sub}, apply: sub.applyRules})
+				}
+			case "anyOf":
+				f = f.anyOf(subs)
+			case "oneOf":
+				f = f.oneOf(subs)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.nullability(keywords, map[string]bool{}) == nullAllowed {
+		field := *f
+		field.nullable = true
+		f = &field
+	}
+	return f, nil
+}
+
+// nullability tells whether a subschema accepts null, null counting as missing unless the type,
+// enum or const of the subschema explicitly allow it
+type nullability int
+
+const (
+	nullUnspecified nullability = iota
+	nullAllowed
+	nullRejected
+)
+
+// and combines the nullability of two subschemas the value must both match
+func (n nullability) and(other nullability) nullability {
+	if n == nullRejected || other == nullRejected {
+		return nullRejected
+	}
+	if n == nullAllowed || other == nullAllowed {
+		return nullAllowed
+	}
+	return nullUnspecified
+}
+
+// nullability of the subschema doc, refs holding the references being followed
+func (c *schemaCompiler) nullability(doc interface{}, refs map[string]bool) nullability {
+	keywords, ok := doc.(map[string]interface{})
+	if !ok {
+		return nullUnspecified
+	}
+
+	n := nullUnspecified
+	allows := func(allowed bool) {
+		if allowed {
+			n = n.and(nullAllowed)
+		} else {
+			n = n.and(nullRejected)
+		}
+	}
+	if value, ok := keywords["type"]; ok {
+		types, _ := value.([]interface{})
+		allows(value == "null" || containsJSON(types, "null"))
+	}
+	if value, ok := keywords["const"]; ok {
+		allows(value == nil)
+	}
+	if value, ok := keywords["enum"]; ok {
+		values, _ := value.([]interface{})
+		allows(containsJSON(values, nil))
+	}
+	if ref, ok := keywords["$ref"].(string); ok && !refs[ref] {
+		if target, ok := resolvePointer(c.root, strings.TrimPrefix(ref, "#")); ok {
+			refs[ref] = true
+			n = n.and(c.nullability(target, refs))
+			delete(refs, ref)
+		}
+	}
+	if subs, ok := keywords["allOf"].([]interface{}); ok {
+		for _, sub := range subs {
+			n = n.and(c.nullability(sub, refs))
+		}
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		subs, ok := keywords[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		allowed, rejected := 0, 0
+		for _, sub := range subs {
+			switch c.nullability(sub, refs) {
+			case nullAllowed:
+				allowed++
+			case nullRejected:
+				rejected++
+			}
+		}
+		switch {
+		case allowed == 1 || allowed > 1 && keyword == "anyOf":
+			n = n.and(nullAllowed)
+		case allowed > 1 || rejected == len(subs):
+			// null matching several alternatives fails oneOf
+			n = n.and(nullRejected)
+		}
+	}
+	return n
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *schemaCompiler) subschemas(value interface{}, pointer string) ([]*SchemaField, error) {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, c.errorf(pointer, "expected a non-empty array of schemas")
+	}
+	subs := make([]*SchemaField, len(list))
+	for i, sub := range list {
+		var err error
+		if subs[i], err = c.rules(Elem(), sub, pointer+"/"+strconv.Itoa(i)); err != nil {
+			return nil, err
+		}
+	}
+	return subs, nil
+}
+
+// ref applies the subschema at a local reference, compiled once so that recursive schemas terminate
+func (c *schemaCompiler) ref(f *SchemaField, value interface{}, pointer string) (*SchemaField, error) {
+	ref, ok := value.(string)
+	if !ok || !strings.HasPrefix(ref, "#") {
+		return nil, c.errorf(pointer, "only local references are supported, got %v", value)
+	}
+
+	target, ok := c.refs[ref]
+	if !ok {
+		doc, ok := resolvePointer(c.root, strings.TrimPrefix(ref, "#"))
+		if !ok {
+			return nil, c.errorf(pointer, "unresolved reference %q", ref)
+		}
+		// the placeholder is filled once compiled, references met meanwhile share it
+		target = Elem()
+		c.refs[ref] = target
+		compiled, err := c.rules(Elem(), doc, strings.TrimPrefix(ref, "#"))
+		if err != nil {
+			return nil, err
+		}
+		*target = *compiled
+	}
+	return f.with(schemaRule{code: "$ref", args: []interface{}{ref}, apply: func(v *Validator) *Validator {
+		return target.applyRules(v)
+	}}), nil
+}
+
+func (c *schemaCompiler) typeRule(f *SchemaField, value interface{}, pointer string) (*SchemaField, error) {
+	var types []string
+	switch value := value.(type) {
+	case string:
+		types = []string{value}
+	case []interface{}:
+		for _, t := range value {
+			t, ok := t.(string)
+			if !ok {
+				return nil, c.errorf(pointer, "expected a type name or an array of type names")
+			}
+			types = append(types, t)
+		}
+	default:
+		return nil, c.errorf(pointer, "expected a type name or an array of type names")
+	}
+	for _, t := range types {
+		switch t {
+		case "string", "number", "integer", "boolean", "object", "array", "null":
+		default:
+			return nil, c.errorf(pointer, "unknown type %q", t)
+		}
+	}
+	return f.with(schemaRule{code: "type", args: []interface{}{value}, apply: func(v *Validator) *Validator {
+		return v.jsonType(types)
+	}}), nil
+}
+
+func jsonFormat(f *SchemaField, value interface{}) *SchemaField {
+	switch value {
+	case "email":
+		return f.Email()
+	case "uri":
+		return f.jsonURI()
+	case "date":
+		return f.Date()
+	case "ipv4":
+		return f.IPv4()
+	case "ipv6":
+		return f.IPv6()
+	case "date-time":
+		return f.Date(time.RFC3339)
+	}
+	return f
+}
+
+// jsonPattern is Match() accepting the empty string when the pattern does
+func (f *SchemaField) jsonPattern(pattern *regexp.Regexp) *SchemaField {
+	return f.with(schemaRule{code: CodeMatch, args: []interface{}{pattern}, apply: func(v *Validator) *Validator {
+		if v.commonReturnCase() {
+			return v
+		}
+		data, ok := v.data.(string)
+		if !ok {
+			v.typeMismatch("string")
+			return v
+		}
+		if !pattern.MatchString(data) {
+			v.appendError(ValidationError{
+				Field:   v.fieldName,
+				Code:    CodeMatch,
+				Message: "must match the pattern",
+				Params:  map[string]interface{}{"pattern": pattern.String()},
+				Value:   v.data,
+			})
+		}
+		return v
+	}})
+}
+
+// jsonURI checks the uri format, any absolute uri is valid, e.g. mailto:ann@example.com or
+// urn:isbn:0451450523, unlike Url() which expects a web url
+func (f *SchemaField) jsonURI() *SchemaField {
+	return f.with(schemaRule{code: CodeUrl, apply: func(v *Validator) *Validator {
+		if v.commonReturnCase() {
+			return v
+		}
+		data, ok := v.data.(string)
+		if !ok {
+			v.typeMismatch("string")
+			return v
+		}
+		if u, err := url.Parse(data); err != nil || u.Scheme == "" || strings.ContainsAny(data, " \t\r\n") {
+			v.appendError(ValidationError{
+				Field:   v.fieldName,
+				Code:    CodeUrl,
+				Message: "must be a valid url",
+				Value:   v.data,
+			})
+		}
+		return v
+	}})
+}
+
+func (f *SchemaField) jsonLength(keyword string, n int) *SchemaField {
+	return f.with(schemaRule{code: keyword, args: []interface{}{n}, apply: func(v *Validator) *Validator {
+		if v.commonReturnCase() {
+			return v
+		}
+		var length int
+		switch keyword {
+		case "minLength", "maxLength":
+			data, ok := v.data.(string)
+			if !ok {
+				v.typeMismatch("string")
+				return v
+			}
+			// JSON Schema counts characters, not bytes
+			length = utf8.RuneCountInString(data)
+		default:
+			rv := reflect.ValueOf(v.data)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				v.typeMismatch("array")
+				return v
+			}
+			length = rv.Len()
+		}
+
+		switch {
+		case (keyword == "minLength" || keyword == "minItems") && length < n:
+			v.appendError(ValidationError{
+				Field:   v.fieldName,
+				Code:    CodeMin,
+				Message: "must be greater than or equal to " + strconv.Itoa(n),
+				Params:  map[string]interface{}{"min": n},
+				Value:   v.data,
+			})
+		case (keyword == "maxLength" || keyword == "maxItems") && length > n:
+			v.appendError(ValidationError{
+				Field:   v.fieldName,
+				Code:    CodeMax,
+				Message: "must be less than or equal to " + strconv.Itoa(n),
+				Params:  map[string]interface{}{"max": n},
+				Value:   v.data,
+			})
+		}
+		return v
+	}})
+}
+
+func (f *SchemaField) jsonBound(keyword string, bound float64) *SchemaField {
+	return f.with(schemaRule{code: keyword, args: []interface{}{bound}, apply: func(v *Validator) *Validator {
+		if v.commonReturnCase() {
+			return v
+		}
+		cmp, ok := compareNumbers(jsonValue(v.data), bound)
+		if !ok {
+			v.typeMismatch("number")
+			return v
+		}
+
+		text := strconv.FormatFloat(bound, 'f', -1, 64)
+		var err ValidationError
+		switch {
+		case keyword == "minimum" && cmp < 0:
+			err = ValidationError{Code: CodeMin, Message: "must be greater than or equal to " + text, Params: map[string]interface{}{"min": bound}}
+		case keyword == "maximum" && cmp > 0:
+			err = ValidationError{Code: CodeMax, Message: "must be less than or equal to " + text, Params: map[string]interface{}{"max": bound}}
+		case keyword == "exclusiveMinimum" && cmp <= 0:
+			err = ValidationError{Code: CodeExclusiveMin, Message: "must be greater than " + text, Params: map[string]interface{}{"min": bound}}
+		case keyword == "exclusiveMaximum" && cmp >= 0:
+			err = ValidationError{Code: CodeExclusiveMax, Message: "must be less than " + text, Params: map[string]interface{}{"max": bound}}
+		default:
+			return v
+		}
+		err.Field = v.fieldName
+		err.Value = v.data
+		v.appendError(err)
+		return v
+	}})
+}
+
+// oneOf passes if exactly one of the alternatives passes
+func (f *SchemaField) oneOf(alternatives []*SchemaField) *SchemaField {
+	args := make([]interface{}, len(alternatives))
+	for i, alternative := range alternatives {
+		args[i] = alternative
+	}
+	return f.with(schemaRule{code: "one_of", args: args, apply: func(v *Validator) *Validator {
+		if v.commonReturnCase() {
+			return v
+		}
+		passed := 0
+		// CustomCtx() checks of the alternatives that passed their synchronous rules
+		var pending [][]asyncField
+		for _, alternative := range alternatives {
+			errs, async := v.attempt(alternative)
+			switch {
+			case len(errs) > 0:
+			case len(async) == 0:
+				passed++
+			default:
+				pending = append(pending, async)
+			}
+		}
+		if len(pending) == 0 || passed > 1 {
+			if passed != 1 {
+				err := oneOfError(passed)
+				err.Field = v.fieldName
+				err.Value = v.data
+				v.appendError(err)
+			}
+			return v
+		}
+
+		// the alternatives whose checks pass when ValidateCtx() runs them count as matched
+		return v.CustomCtx(func(ctx context.Context, value interface{}) error {
+			matched := passed
+			for _, fields := range pending {
+				errs, completed := runFields(ctx, fields)
+				if !completed {
+					// reported unchecked by ValidateCtx, an interrupted alternative neither passes nor fails
+					return ctx.Err()
+				}
+				if len(errs) == 0 {
+					matched++
+				}
+			}
+			if matched != 1 {
+				return oneOfError(matched)
+			}
+			return nil
+		})
+	}})
+}
+
+func oneOfError(matched int) ValidationError {
+	return ValidationError{
+		Code:    CodeOneOf,
+		Message: "must match exactly one schema",
+		Params:  map[string]interface{}{"matched": matched},
+	}
+}
+
+// attempt applies the rules of alternative to a scratch copy of the current field, so that a failing
+// alternative leaves no trace, returning its errors and its CustomCtx() checks
+func (v *Validator) attempt(alternative *SchemaField) ([]ValidationError, []asyncField) {
+	scratch := *v
+	scratch.errors = nil
+	scratch.async = nil
+	scratch.values = append([]namedValue(nil), v.values...)
+	alternative.applyRules(&scratch)
+	return scratch.errors, scratch.async
+}
+
+func (v *Validator) jsonType(types []string) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	actual := jsonTypeOf(v.data)
+	for _, t := range types {
+		if t == actual || t == "number" && actual == "integer" {
+			return v
+		}
+	}
+	expected := strings.Join(types, " or ")
+	v.appendError(ValidationError{
+		Field:   v.fieldName,
+		Code:    CodeTypeMismatch,
+		Message: "must be of type " + expected + ", got " + actual,
+		Params:  map[string]interface{}{"expected": expected, "actual": actual},
+		Value:   v.data,
+	})
+	return v
+}
+
+func (v *Validator) jsonConst(value interface{}) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	if !jsonEqual(v.data, value) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeConst,
+			Message: "must be equal to " + fmt.Sprint(jsonValue(value)),
+			Params:  map[string]interface{}{"value": jsonValue(value)},
+			Value:   v.data,
+		})
+	}
+	return v
+}
+
+func (v *Validator) jsonEnum(values []interface{}) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	texts := make([]string, len(values))
+	for i, value := range values {
+		if jsonEqual(v.data, value) {
+			return v
+		}
+		texts[i] = fmt.Sprint(jsonValue(value))
+	}
+	v.appendError(ValidationError{
+		Field:   v.fieldName,
+		Code:    CodeEnum,
+		Message: "must be one of " + strings.Join(texts, ", "),
+		Params:  map[string]interface{}{"values": strings.Join(texts, ", ")},
+		Value:   v.data,
+	})
+	return v
+}
+
+// jsonTypeOf returns the JSON type of a decoded value, or of the Go value it would be encoded from
+func jsonTypeOf(value interface{}) string {
+	switch value := jsonValue(value).(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) && !math.IsInf(value, 0) {
+			return "integer"
+		}
+		return "number"
+	case float32:
+		if float64(value) == math.Trunc(float64(value)) {
+			return "integer"
+		}
+		return "number"
+	}
+	if _, _, ok := integerValue(value); ok {
+		return "integer"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// jsonValue turns a json.Number into an int64 or float64
+func jsonValue(value interface{}) interface{} {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// jsonEqual compares JSON values, numbers by value whatever their Go type
+func jsonEqual(a, b interface{}) bool {
+	a, b = jsonValue(a), jsonValue(b)
+	if cmp, ok := compareNumbers(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonInt(value interface{}) (int, bool) {
+	f, ok := jsonFloat(value)
+	if !ok || f != math.Trunc(f) || f > math.MaxInt32 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func jsonFloat(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return toFloat64(value)
+}
+
+// resolvePointer returns the value at a JSON Pointer, e.g. "/$defs/address"
+func resolvePointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = node[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			doc = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
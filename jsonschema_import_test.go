@@ -0,0 +1,338 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const orderJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "email", "items", "status"],
+  "properties": {
+    "id": {"type": "integer", "minimum": 1},
+    "email": {"type": "string", "format": "email", "maxLength": 30},
+    "status": {"enum": ["open", "paid"]},
+    "version": {"const": 2},
+    "discount": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+    "created": {"type": "string", "format": "date-time"},
+    "address": {"$ref": "#/$defs/address"},
+    "contact": {"oneOf": [{"format": "email"}, {"pattern": "^\\+[0-9]+$"}]},
+    "tags": {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 2}},
+    "items": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "type": "object",
+        "required": ["sku"],
+        "properties": {
+          "sku": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{4}$"},
+          "quantity": {"anyOf": [{"type": "integer", "minimum": 1}, {"const": "unlimited"}]}
+        }
+      }
+    }
+  },
+  "$defs": {
+    "address": {
+      "type": "object",
+      "required": ["city"],
+      "properties": {
+        "city": {"type": "string", "minLength": 2},
+        "parent": {"$ref": "#/$defs/address"}
+      }
+    }
+  }
+}`
+
+func loadOrderSchema(t *testing.T) *Schema {
+	t.Helper()
+	schema, err := LoadJSONSchema([]byte(orderJSONSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func decodePayload(t *testing.T, payload string) map[string]interface{} {
+	t.Helper()
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCompileJSONSchemaValid(t *testing.T) {
+	schema := loadOrderSchema(t)
+	data := decodePayload(t, `{
+		"id": 7, "email": "test@example.com", "status": "paid", "version": 2, "discount": 0.5,
+		"created": "2024-05-01T10:00:00Z", "contact": "+123456",
+		"address": {"city": "Berlin", "parent": {"city": "DE"}},
+		"tags": ["go", "api"],
+		"items": [{"sku": "ABC-1234", "quantity": 3}, {"sku": "XYZ-0001", "quantity": "unlimited"}]
+	}`)
+	if errs := schema.Validate(data); errs != nil {
+		t.Errorf("Validate() = %v", errs)
+	}
+}
+
+func TestCompileJSONSchemaErrors(t *testing.T) {
+	schema := loadOrderSchema(t)
+	data := decodePayload(t, `{
+		"id": 1.5, "email": "invalid", "status": "shipped", "version": 3, "discount": 1,
+		"created": "yesterday", "contact": "nobody",
+		"address": {"parent": {"city": "B"}},
+		"tags": ["go", "a", "api"],
+		"items": [{"quantity": 0}, {"sku": "abc", "quantity": "many"}]
+	}`)
+
+	want := map[string]string{
+		"/id":                  CodeTypeMismatch,
+		"/email":               CodeEmail,
+		"/status":              CodeEnum,
+		"/version":             CodeConst,
+		"/discount":            CodeExclusiveMax,
+		"/created":             CodeDate,
+		"/contact":             CodeOneOf,
+		"/address/city":        CodeRequired,
+		"/address/parent/city": CodeMin,
+		"/tags":                CodeMax,
+		"/items/0/sku":         CodeRequired,
+		"/items/0/quantity":    CodeMin,
+		"/items/1/sku":         CodeMatch,
+		"/items/1/quantity":    CodeTypeMismatch,
+	}
+	errs := schema.Validate(data)
+	got := map[string]string{}
+	for _, err := range errs {
+		got[err.Pointer()] = err.Code
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() errors = %v\nwant %v", got, want)
+	}
+
+	errs = schema.Validate(decodePayload(t, `{}`))
+	if len(errs) != 4 || errs.Field("status")[0].Code != CodeRequired {
+		t.Errorf("missing required properties = %v", errs)
+	}
+}
+
+func TestCompileJSONSchemaMessages(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(`{"properties": {
+		"size": {"enum": ["s", "m", 1.5]},
+		"ratio": {"exclusiveMinimum": 0.25},
+		"name": {"minLength": 3}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := schema.Validate(map[string]interface{}{"size": "xl", "ratio": 0.25, "name": "äö"})
+	messages := map[string]string{}
+	for _, err := range errs {
+		messages[err.Field] = err.Message
+	}
+	want := map[string]string{
+		"name":  "must be greater than or equal to 3",
+		"ratio": "must be greater than 0.25",
+		"size":  "must be one of s, m, 1.5",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("messages = %v", messages)
+	}
+}
+
+func TestCompileJSONSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		want   string
+	}{
+		{"pattern matching the empty string", `{"type": "string", "pattern": "^[a-z]*$"}`, `""`, ""},
+		{"pattern rejecting the empty string", `{"type": "string", "pattern": "^[a-z]+$"}`, `""`, CodeMatch},
+		{"nullable type", `{"type": ["string", "null"], "minLength": 2}`, `null`, ""},
+		{"nullable type with a value", `{"type": ["string", "null"], "minLength": 2}`, `"a"`, CodeMin},
+		{"non-nullable type", `{"type": "string"}`, `null`, CodeRequired},
+		{"enum with null", `{"enum": ["x", null]}`, `null`, ""},
+		{"enum without null", `{"enum": ["x", "y"]}`, `null`, CodeRequired},
+		{"const null", `{"const": null}`, `null`, ""},
+		{"nullable type and enum without null", `{"type": ["string", "null"], "enum": ["x"]}`, `null`, CodeRequired},
+		{"anyOf with a null branch", `{"anyOf": [{"type": "integer"}, {"type": "null"}]}`, `null`, ""},
+		{"nullable reference", `{"$ref": "#/$defs/name"}`, `null`, ""},
+		{"uri mailto", `{"type": "string", "format": "uri"}`, `"mailto:ann@example.com"`, ""},
+		{"uri urn", `{"type": "string", "format": "uri"}`, `"urn:isbn:0451450523"`, ""},
+		{"uri http", `{"type": "string", "format": "uri"}`, `"https://example.com/a?b=c"`, ""},
+		{"uri without scheme", `{"type": "string", "format": "uri"}`, `"/relative/path"`, CodeUrl},
+		{"uri with spaces", `{"type": "string", "format": "uri"}`, `"not a uri"`, CodeUrl},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := LoadJSONSchema([]byte(`{"required": ["a"], "properties": {"a": ` + tt.schema + `},
+				"$defs": {"name": {"type": ["string", "null"]}}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs := schema.Validate(decodePayload(t, `{"a": `+tt.value+`}`))
+			got := ""
+			if len(errs) > 0 {
+				got = errs[0].Code
+			}
+			if len(errs) > 1 || got != tt.want {
+				t.Errorf("Validate(%s) = %v; want %q", tt.value, errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileJSONSchemaNullRequired(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(`{"required": ["name"], "properties": {"name": {"type": ["string", "null"]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := schema.Validate(decodePayload(t, `{}`)); len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("a nullable property left out should be missing, got %v", errs)
+	}
+
+	type user struct {
+		Name *string `json:"name"`
+	}
+	if errs, err := schema.ValidateJSON([]byte(`{"name": null}`), &user{}); err != nil || errs != nil {
+		t.Errorf("ValidateJSON() with a null nullable property = %v, %v", errs, err)
+	}
+	if errs, _ := schema.ValidateJSON([]byte(`{}`), &user{}); len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("ValidateJSON() without the nullable property = %v", errs)
+	}
+}
+
+func TestCompileJSONSchemaOneOfCtx(t *testing.T) {
+	taken := func(ctx context.Context, value interface{}) error {
+		if value == "admin" {
+			return errors.New("is already taken")
+		}
+		return nil
+	}
+	field := Field("user").oneOf([]*SchemaField{Elem().CustomCtx(taken), Elem().Email()})
+	schema := NewSchema(field)
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"ctrix", ""},
+		{"admin", CodeOneOf},
+		{"ann@example.com", CodeOneOf},
+	}
+	for _, tt := range tests {
+		errs, err := schema.ValidateCtx(context.Background(), map[string]interface{}{"user": tt.value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if len(errs) > 0 {
+			got = errs[0].Code
+		}
+		if len(errs) > 1 || got != tt.want {
+			t.Errorf("ValidateCtx(%q) = %v; want %q", tt.value, errs, tt.want)
+		}
+	}
+}
+
+func TestCompileJSONSchemaOneOfCtxInterrupted(t *testing.T) {
+	hang := func(ctx context.Context, value interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	schema := NewSchema(Field("user").oneOf([]*SchemaField{Elem().CustomCtx(hang), Elem().Email()}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	errs, err := schema.ValidateCtx(ctx, map[string]interface{}{"user": "ctrix"})
+	if !errors.Is(err, context.DeadlineExceeded) || len(errs) != 1 || errs[0].Code != CodeUnchecked {
+		t.Errorf("ValidateCtx() with an interrupted alternative = %v, %v; want the field unchecked", errs, err)
+	}
+}
+
+func TestCompileJSONSchemaInvalid(t *testing.T) {
+	tests := []struct {
+		doc     string
+		pointer string
+	}{
+		{`{"type": "array"}`, "/type"},
+		{`{"properties": {"a": {"minLength": -1}}}`, "/properties/a/minLength"},
+		{`{"properties": {"a": {"minimum": "x"}}}`, "/properties/a/minimum"},
+		{`{"properties": {"a": {"pattern": "("}}}`, "/properties/a/pattern"},
+		{`{"properties": {"a": {"type": "text"}}}`, "/properties/a/type"},
+		{`{"properties": {"a/b": {"$ref": "#/$defs/missing"}}}`, "/properties/a~1b/$ref"},
+		{`{"properties": {"a": {"$ref": "other.json"}}}`, "/properties/a/$ref"},
+		{`{"properties": {"a": {"oneOf": []}}}`, "/properties/a/oneOf"},
+		{`{"required": "a"}`, "/required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc, func(t *testing.T) {
+			_, err := LoadJSONSchema([]byte(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), " at "+tt.pointer+":") {
+				t.Errorf("LoadJSONSchema() = %v; want an error at %s", err, tt.pointer)
+			}
+		})
+	}
+
+	if _, err := LoadJSONSchema([]byte(`{`)); err == nil {
+		t.Errorf("LoadJSONSchema() should reject invalid JSON")
+	}
+}
+
+func TestJSONSchemaRoundTrip(t *testing.T) {
+	schema, err := LoadJSONSchema([]byte(`{"type": "object", "required": ["id"], "properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"kind": {"enum": ["a", "b"]}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSONEqual(t, schema.JSONSchema(), `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"kind": {"enum": ["a", "b"]}
+		}
+	}`)
+}
+
+func TestCompileJSONSchemaAt(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{"components": {"schemas": {
+		"User": {"type": "object", "required": ["name"], "properties": {"name": {"$ref": "#/components/schemas/Name"}}},
+		"Name": {"type": "string", "minLength": 2}
+	}}}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := CompileJSONSchemaAt(doc, "/components/schemas/User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := schema.Validate(map[string]interface{}{"name": "A"}); len(errs) != 1 || errs[0].Code != CodeMin {
+		t.Errorf("Validate() = %v", errs)
+	}
+
+	field, err := CompileJSONSchemaField("name", doc, "/components/schemas/Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := NewSchema(field).Validate(map[string]interface{}{"name": 5.0}); len(errs) != 1 || errs[0].Code != CodeTypeMismatch {
+		t.Errorf("Validate() = %v", errs)
+	}
+
+	if _, err := CompileJSONSchemaAt(doc, "/components/schemas/Missing"); err == nil || !strings.Contains(err.Error(), "at /components/schemas/Missing:") {
+		t.Errorf("CompileJSONSchemaAt() = %v; want an error at the missing location", err)
+	}
+}
@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestJSONSchema(t *testing.T) {
+	address := NewSchema(Field("city").Min(2), Field("zip").NotRequired().Numeric())
+	schema := NewSchema(
+		Field("email").Email().Max(30),
+		Field("username").Min(5).Max(25),
+		Field("slug").NotRequired().Match(regexp.MustCompile(`^[a-z-]+$`)).AlphaNumeric(),
+		Field("address").Nested(address),
+		Field("tags").Each(Elem().Min(2)),
+		MustParseRules("contact", "email|url"),
+		Field("confirm").EqField("password"),
+		Field("state").RequiredIf("country", "US"),
+		Field("sku").Rule("sku"),
+	)
+
+	doc := schema.JSONSchema()
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "properties": {
+    "address": {
+      "properties": {
+        "city": {"minLength": 2, "minimum": 2},
+        "zip": {"pattern": "^[0-9]+$", "type": "string"}
+      },
+      "required": ["city"],
+      "type": "object"
+    },
+    "confirm": {"x-validator-rules": [{"params": ["password"], "rule": "eq_field"}]},
+    "contact": {"anyOf": [{"format": "email", "type": "string"}, {"format": "uri", "pattern": "^([hH][tT][tT][pP]|[hH][tT][tT][pP][sS]|[fF][tT][pP]):", "type": "string"}]},
+    "email": {"format": "email", "maxLength": 30, "maximum": 30, "type": "string"},
+    "slug": {"allOf": [{"pattern": "^[a-zA-Z0-9]+$"}], "pattern": "^[a-z-]+$", "type": "string"},
+    "sku": {"x-validator-rules": [{"rule": "sku"}]},
+    "state": {"x-validator-rules": [{"params": ["country", "US"], "rule": "required_if"}]},
+    "tags": {"items": {"minLength": 2, "minimum": 2}, "type": "array"},
+    "username": {"maxLength": 25, "maximum": 25, "minLength": 5, "minimum": 5}
+  },
+  "required": ["email", "username", "address", "tags", "contact", "confirm", "sku"],
+  "type": "object"
+}`
+	assertJSONEqual(t, doc, want)
+}
+
+func TestJSONSchemaURLAndEmpty(t *testing.T) {
+	schema := NewSchema(
+		Field("homepage").Url(URLPolicy{Schemes: []string{"https"}}),
+		Field("webhook").Url(URLPolicy{Schemes: []string{"https"}, AllowedHosts: []string{"example.com"}}),
+		MustParseRules("nickname", "omitempty,min=3"),
+		MustParseRules("bio", "omitempty,alpha"),
+	)
+
+	doc := schema.JSONSchema()
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "properties": {
+    "bio": {"anyOf": [{"const": ""}, {"pattern": "^[a-zA-Z]+$"}], "type": "string"},
+    "homepage": {"format": "uri", "pattern": "^([hH][tT][tT][pP][sS]):", "type": "string"},
+    "nickname": {"anyOf": [{"enum": ["", 0, false, [], {}]}, {"minLength": 3, "minimum": 3}]},
+    "webhook": {
+      "format": "uri",
+      "pattern": "^([hH][tT][tT][pP][sS]):",
+      "type": "string",
+      "x-validator-rules": [{"params": [{"AllowedHosts": ["example.com"], "DeniedHosts": null, "ForbidCredentials": false,
+        "ForbidFragment": false, "ForbidQuery": false, "MaxLength": 0, "RequireFragment": false, "RequireQuery": false,
+        "Schemes": ["https"]}], "rule": "url"}]
+    }
+  },
+  "required": ["homepage", "webhook"],
+  "type": "object"
+}`
+	assertJSONEqual(t, doc, want)
+
+	// the exported document accepts and rejects the same values as the schema
+	data, _ := json.Marshal(doc)
+	imported, err := LoadJSONSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		value map[string]interface{}
+		valid bool
+	}{
+		{map[string]interface{}{"homepage": "HTTPS://example.com", "webhook": "https://example.com", "nickname": "", "bio": ""}, true},
+		{map[string]interface{}{"homepage": "https://example.com", "webhook": "https://example.com", "nickname": "ann"}, true},
+		{map[string]interface{}{"homepage": "http://example.com", "webhook": "https://example.com"}, false},
+		{map[string]interface{}{"homepage": "https://example.com", "webhook": "https://example.com", "nickname": "an"}, false},
+	}
+	for _, tt := range tests {
+		if errs := schema.Validate(tt.value); (errs == nil) != tt.valid {
+			t.Errorf("Validate(%v) = %v; want valid %v", tt.value, errs, tt.valid)
+		}
+		if errs := imported.Validate(tt.value); (errs == nil) != tt.valid {
+			t.Errorf("exported schema: Validate(%v) = %v; want valid %v", tt.value, errs, tt.valid)
+		}
+	}
+}
+
+type jsonSchemaNode struct {
+	Name     string            `json:"name" validate:"min=1,max=20"`
+	Count    uint8             `json:"count" validate:"omitempty,max=10"`
+	Weight   float64           `json:"weight" validate:"min=1"`
+	Tags     []string          `json:"tags" validate:"max=3"`
+	Created  time.Time         `json:"created" validate:"required"`
+	Children []jsonSchemaNode  `json:"children"`
+	Parent   *jsonSchemaNode   `json:"parent"`
+	Labels   map[string]string `json:"labels" validate:"omitempty"`
+}
+
+func TestJSONSchemaStruct(t *testing.T) {
+	doc := StructSchema(reflect.TypeOf(jsonSchemaNode{})).JSONSchema()
+	node := `{
+  "properties": {
+    "children": {"items": {"$ref": "#/$defs/jsonSchemaNode"}, "type": "array"},
+    "count": {"anyOf": [{"const": 0}, {"maximum": 10}], "type": "integer"},
+    "created": {"format": "date-time", "type": "string"},
+    "labels": {"type": "object"},
+    "name": {"maxLength": 20, "minLength": 1, "type": "string"},
+    "parent": {"$ref": "#/$defs/jsonSchemaNode", "type": "object"},
+    "tags": {"maxItems": 3, "type": "array"},
+    "weight": {"minimum": 1, "type": "number"}
+  },
+  "required": ["name", "weight", "tags", "created"],
+  "type": "object"
+}`
+	var want, def map[string]interface{}
+	if err := json.Unmarshal([]byte(node), &want); err != nil {
+		t.Fatal(err)
+	}
+	json.Unmarshal([]byte(node), &def)
+	want["$schema"] = JSONSchemaDialect
+	want["$defs"] = map[string]interface{}{"jsonSchemaNode": def}
+
+	wantJSON, _ := json.Marshal(want)
+	assertJSONEqual(t, doc, string(wantJSON))
+}
+
+func assertJSONEqual(t *testing.T, doc map[string]interface{}, want string) {
+	t.Helper()
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotValue, wantValue interface{}
+	json.Unmarshal(got, &gotValue)
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSONSchema() = %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one element of a field path, a key or (when index >= 0) a slice index
+type pathSegment struct {
+	key   string
+	index int
+}
+
+// fieldState is the part of Validator describing the current field, saved around Nested/Each scopes
+type fieldState struct {
+	fieldName     string
+	data          interface{}
+	requiredField bool
+	zeroIsEmpty   bool
+	dateLayouts   []string
+	foundErr      bool
+	scopePath     []pathSegment
+	fieldPath     []pathSegment
+	fieldID       int
+}
+
+func (v *Validator) saveField() fieldState {
+	return fieldState{
+		fieldName:     v.fieldName,
+		data:          v.data,
+		requiredField: v.requiredField,
+		zeroIsEmpty:   v.zeroIsEmpty,
+		dateLayouts:   v.dateLayouts,
+		foundErr:      v.foundErr,
+		scopePath:     v.scopePath,
+		fieldPath:     v.fieldPath,
+		fieldID:       v.fieldID,
+	}
+}
+
+func (v *Validator) restoreField(s fieldState) {
+	v.remember()
+	v.fieldName = s.fieldName
+	v.data = s.data
+	v.requiredField = s.requiredField
+	v.zeroIsEmpty = s.zeroIsEmpty
+	v.dateLayouts = s.dateLayouts
+	v.foundErr = s.foundErr
+	v.scopePath = s.scopePath
+	v.fieldPath = s.fieldPath
+	v.fieldID = s.fieldID
+}
+
+// currentPath returns the path of the current field
+func (v *Validator) currentPath() []pathSegment {
+	if v.fieldPath == nil {
+		return []pathSegment{{key: v.fieldName, index: -1}}
+	}
+	return v.fieldPath
+}
+
+func (v *Validator) setFieldPath(path []pathSegment) {
+	v.fieldPath = path
+	v.fieldName = renderPath(path)
+}
+
+// enterScope makes the current field the parent of the fields declared next
+func (v *Validator) enterScope() {
+	v.scopePath = v.currentPath()
+	v.fieldPath = v.scopePath
+}
+
+// enterElement makes an element of parent the current field and the scope of fields declared next
+func (v *Validator) enterElement(parent []pathSegment, segment pathSegment, data interface{}) {
+	v.setFieldPath(append(parent[:len(parent):len(parent)], segment))
+	v.scopePath = v.fieldPath
+	v.data = deref(data)
+	v.requiredField = true
+	v.zeroIsEmpty = false
+	v.dateLayouts = nil
+	v.foundErr = false
+}
+
+// errorPath returns the path of the current field as ValidationError.Path
+func (v *Validator) errorPath() []string {
+	if v.fieldPath == nil {
+		return []string{v.fieldName}
+	}
+	path := make([]string, len(v.fieldPath))
+	for i, segment := range v.fieldPath {
+		if segment.index >= 0 {
+			path[i] = strconv.Itoa(segment.index)
+		} else {
+			path[i] = segment.key
+		}
+	}
+	return path
+}
+
+// renderPath renders a path the way it is reported in ValidationError.Field, e.g. "items[3].sku"
+func renderPath(path []pathSegment) string {
+	var b strings.Builder
+	for i, segment := range path {
+		if segment.index >= 0 {
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(segment.index))
+			b.WriteByte(']')
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment.key)
+	}
+	return b.String()
+}
+
+/*
+This function validates the fields of a nested object
+
+- fieldName: name of the nested object, prefixed to the name of every field declared inside
+
+- fn: declares the nested fields with NextField()
+
+returns: *Validator
+
+Example:
+
+	validator := NewValidator("name", user.Name).Min(3).
+	    Nested("address", func(v *Validator) {
+	        v.NextField("city", user.Address.City).Min(2)
+	        v.NextField("zip", user.Address.Zip).Numeric()
+	    })
+	// errors are reported as "address.city" and "address.zip"
+*/
+func (v *Validator) Nested(fieldName string, fn func(*Validator)) *Validator {
+	saved := v.saveField()
+	v.NextField(fieldName, nil)
+	v.enterScope()
+	fn(v)
+	v.restoreField(saved)
+	return v
+}
+
+/*
+This function validates every element of a slice or array
+
+- fn: validates the current element, available through Value(), errors are reported as "field[i]".
+Fields declared with NextField() inside fn are reported as "field[i].name"
+
+returns: *Validator
+
+Example:
+
+	validator := NewValidator("items", order.Items).Each(func(v *Validator) {
+	    item := v.Value().(Item)
+	    v.NextField("sku", item.SKU).AlphaNumeric()
+	    v.NextField("quantity", item.Quantity).Min(1)
+	})
+*/
+func (v *Validator) Each(fn func(*Validator)) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	rv := reflect.ValueOf(v.data)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		v.typeMismatch("slice or array")
+		return v
+	}
+
+	saved := v.saveField()
+	parent := v.currentPath()
+	for i := 0; i < rv.Len(); i++ {
+		v.enterElement(parent, pathSegment{index: i}, rv.Index(i).Interface())
+		fn(v)
+	}
+	v.restoreField(saved)
+	return v
+}
+
+/*
+This function validates every key of a map, keys are visited in sorted order
+
+- fn: validates the current key, available through Value(), errors are reported as "field.key"
+
+returns: *Validator
+*/
+func (v *Validator) Keys(fn func(*Validator)) *Validator {
+	return v.eachMapEntry(fn, true)
+}
+
+/*
+This function validates every value of a map, keys are visited in sorted order
+
+- fn: validates the current value, available through Value(), errors are reported as "field.key"
+
+returns: *Validator
+*/
+func (v *Validator) Values(fn func(*Validator)) *Validator {
+	return v.eachMapEntry(fn, false)
+}
+
+func (v *Validator) eachMapEntry(fn func(*Validator), keys bool) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	rv := reflect.ValueOf(v.data)
+	if rv.Kind() != reflect.Map {
+		v.typeMismatch("map")
+		return v
+	}
+
+	entries := rv.MapKeys()
+	names := make([]string, len(entries))
+	for i, key := range entries {
+		names[i] = fmt.Sprint(key.Interface())
+	}
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+
+	saved := v.saveField()
+	parent := v.currentPath()
+	for _, i := range order {
+		data := rv.MapIndex(entries[i]).Interface()
+		if keys {
+			data = entries[i].Interface()
+		}
+		v.enterElement(parent, pathSegment{key: names[i], index: -1}, data)
+		fn(v)
+	}
+	v.restoreField(saved)
+	return v
+}
+
+/*
+This function returns the value of the current field, e.g. the current element inside Each()
+
+returns: interface{}
+*/
+func (v *Validator) Value() interface{} {
+	return v.data
+}
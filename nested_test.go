@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	SKU      string `json:"sku" validate:"alphanumeric"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+type testAddress struct {
+	City string `json:"city" validate:"min=2"`
+	Zip  string `json:"zip" validate:"numeric"`
+}
+
+type testOrder struct {
+	Email   string       `json:"email" validate:"email"`
+	Address testAddress  `json:"address"`
+	Billing *testAddress `json:"billing"`
+	Items   []testItem   `json:"items" validate:"min=1"`
+	Skipped testAddress  `validate:"-"`
+}
+
+func TestNested(t *testing.T) {
+	v := NewValidator("name", "ctrix").Min(3).
+		Nested("address", func(v *Validator) {
+			v.NextField("city", "x").Min(2)
+			v.NextField("zip", "12345").Numeric()
+			v.Nested("geo", func(v *Validator) {
+				v.NextField("lat", "north").Numeric()
+			})
+		})
+	v.NextField("email", "invalid-email").Email()
+
+	errs := v.GetError()
+	want := []struct{ field, pointer string }{
+		{"address.city", "/address/city"},
+		{"address.geo.lat", "/address/geo/lat"},
+		{"email", "/email"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Field != w.field || errs[i].Pointer() != w.pointer {
+			t.Errorf("error %d = %s (%s); want %s (%s)", i, errs[i].Field, errs[i].Pointer(), w.field, w.pointer)
+		}
+	}
+}
+
+func TestEach(t *testing.T) {
+	items := []testItem{{"abc1", 1}, {"ab-2", 0}, {"abc3", 0}}
+
+	errs := NewValidator("items", items).Each(func(v *Validator) {
+		item := v.Value().(testItem)
+		v.NextField("sku", item.SKU).AlphaNumeric()
+		v.NextField("quantity", item.Quantity).NotRequired().ZeroIsEmpty().Min(1)
+	}).GetError()
+
+	if len(errs) != 1 || errs[0].Field != "items[1].sku" || errs[0].Pointer() != "/items/1/sku" {
+		t.Errorf("Each() should report element paths, got %v", errs)
+	}
+	if !reflect.DeepEqual(errs[0].Path, []string{"items", "1", "sku"}) {
+		t.Errorf("Path = %v", errs[0].Path)
+	}
+
+	errs = NewValidator("tags", []string{"go", "x", "validator"}).Each(func(v *Validator) {
+		v.Min(2)
+	}).GetError()
+	if len(errs) != 1 || errs[0].Field != "tags[1]" {
+		t.Errorf("Each() should validate elements directly, got %v", errs)
+	}
+}
+
+func TestKeysValues(t *testing.T) {
+	labels := map[string]string{"env": "prod", "tier": "", "a/b": "x"}
+
+	errs := NewValidator("labels", labels).
+		Keys(func(v *Validator) { v.AlphaNumeric() }).
+		Values(func(v *Validator) { v.ZeroIsEmpty().Alpha() }).
+		GetError()
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if errs[0].Field != "labels.a/b" || errs[0].Code != CodeAlphaNumeric || errs[0].Pointer() != "/labels/a~1b" {
+		t.Errorf("Keys() error = %s %s %s", errs[0].Field, errs[0].Code, errs[0].Pointer())
+	}
+	if errs[1].Field != "labels.tier" || errs[1].Code != CodeRequired {
+		t.Errorf("Values() error = %s %s", errs[1].Field, errs[1].Code)
+	}
+}
+
+func TestSchemaNested(t *testing.T) {
+	itemSchema := NewSchema(Field("sku").AlphaNumeric(), Field("quantity").Min(1))
+	schema := NewSchema(
+		Field("address").Nested(NewSchema(Field("city").Min(2))),
+		Field("items").Each(Elem().Nested(itemSchema)),
+	)
+
+	errs := schema.Validate(map[string]interface{}{
+		"address": map[string]interface{}{"city": "x"},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "abc", "quantity": 2},
+			map[string]interface{}{"sku": "a-b", "quantity": 2},
+		},
+	})
+
+	if len(errs) != 2 || errs[0].Field != "address.city" || errs[1].Field != "items[1].sku" {
+		t.Errorf("Schema nested errors = %v", errs)
+	}
+}
+
+func TestValidateStructNested(t *testing.T) {
+	order := testOrder{
+		Email:   "test@example.com",
+		Address: testAddress{City: "x", Zip: "12345"},
+		Items:   []testItem{{"abc1", 1}, {"abc2", 0}},
+		Skipped: testAddress{City: "x"},
+	}
+
+	errs := ValidateStruct(order)
+	if len(errs) != 2 || errs[0].Field != "address.city" || errs[1].Field != "items[1].quantity" {
+		t.Fatalf("ValidateStruct() nested errors = %v", errs)
+	}
+
+	order.Billing = &testAddress{City: "Berlin", Zip: "1O115"}
+	errs = ValidateStruct(order)
+	if len(errs) != 3 || errs[1].Pointer() != "/billing/zip" {
+		t.Errorf("ValidateStruct() should validate pointers to structs, got %v", errs)
+	}
+}
+
+func TestValidateStructRecursiveType(t *testing.T) {
+	type node struct {
+		Name string `json:"name" validate:"alpha"`
+		Next *node  `json:"next"`
+	}
+
+	errs := ValidateStruct(node{Name: "a", Next: &node{Name: "b", Next: &node{Name: "c1"}}})
+	if len(errs) != 1 || errs[0].Field != "next.next.name" {
+		t.Errorf("ValidateStruct() recursive type errors = %v", errs)
+	}
+}
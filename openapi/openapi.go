@@ -0,0 +1,337 @@
+/*
+Package openapi validates HTTP requests against the operations of an OpenAPI 3.1 document
+
+The document is loaded once, its schemas being compiled with validator.CompileJSONSchemaField(),
+then every request is checked for its path, query, header and cookie parameters and its JSON body.
+Errors are reported as validator.ValidationErrors, like the rest of the validator package.
+
+Example:
+
+	spec, err := openapi.Load("api.yaml")
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	http.HandleFunc("POST /pets", func(w http.ResponseWriter, r *http.Request) {
+	    errs, err := spec.ValidateRequest(r)
+	    if errors.Is(err, openapi.ErrBodyTooLarge) {
+	        http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	        return
+	    }
+	    if err != nil {
+	        http.Error(w, err.Error(), http.StatusBadRequest)
+	        return
+	    }
+	    if errs != nil {
+	        w.WriteHeader(http.StatusUnprocessableEntity)
+	        json.NewEncoder(w).Encode(errs)
+	        return
+	    }
+	    // r.Body can still be read
+	})
+*/
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoOperation is returned by Spec.ValidateRequest() when no operation of the document matches the request
+var ErrNoOperation = errors.New("openapi: no operation matches the request")
+
+// ErrUnsupportedMediaType is returned when the request body has a content type the operation does not accept
+var ErrUnsupportedMediaType = errors.New("openapi: unsupported request content type")
+
+// ErrBodyTooLarge is wrapped by the error returned for a request body over Spec.MaxBodyBytes, to be answered with
+// 413 Request Entity Too Large
+var ErrBodyTooLarge = errors.New("openapi: request body too large")
+
+// DefaultMaxBodyBytes is the size limit of a request body when Spec.MaxBodyBytes is 0
+const DefaultMaxBodyBytes = 1 << 20
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+/*
+Spec is a loaded OpenAPI 3.1 document, safe for concurrent use once configured
+*/
+type Spec struct {
+	// MaxBodyBytes limits the size of a request body, DefaultMaxBodyBytes when 0, no limit when negative
+	MaxBodyBytes int64
+
+	doc        map[string]interface{}
+	operations []*Operation // in routing order, concrete paths first
+	byKey      map[string]*Operation
+	basePaths  []string
+}
+
+/*
+This function loads an OpenAPI 3.1 document from a YAML or JSON file, see Parse()
+
+- path: path of the file
+
+returns: *Spec, and an error when the file cannot be read or the document is invalid
+*/
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	return Parse(data)
+}
+
+/*
+This function parses an OpenAPI 3.1 document, in YAML or JSON, and compiles the schemas of its operations
+
+- data: the document
+
+returns: *Spec, and an error when the document is malformed, is not OpenAPI 3.1 or contains an unsupported schema
+
+Parameters and request bodies may be given inline or as "#/components/..." references, schemas
+may reference each other, recursively included. References to other files are not supported.
+*/
+func Parse(data []byte) (*Spec, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("openapi: invalid document: %w", err)
+	}
+	doc, ok := normalize(raw).(map[string]interface{})
+	if !ok {
+		return nil, errors.New("openapi: invalid document: expected an object")
+	}
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.1.") && version != "3.1" {
+		return nil, fmt.Errorf("openapi: unsupported OpenAPI version %q, expected 3.1", version)
+	}
+
+	s := &Spec{doc: doc, byKey: map[string]*Operation{}}
+	s.basePaths = basePaths(doc)
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, template := range sortedKeys(paths) {
+		pointer := "/paths/" + escapePointer(template)
+		item, itemPointer, err := s.resolve(paths[template], pointer)
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range methods {
+			if _, ok := item[method]; !ok {
+				continue
+			}
+			op, err := s.compileOperation(template, strings.ToUpper(method), item, itemPointer)
+			if err != nil {
+				return nil, err
+			}
+			if op.ID != "" {
+				if _, ok := s.byKey[op.ID]; ok {
+					return nil, fmt.Errorf("openapi: invalid document at %s/operationId: duplicate operationId %q", op.pointer, op.ID)
+				}
+				s.byKey[op.ID] = op
+			}
+			s.byKey[op.Method+" "+op.Path] = op
+			s.operations = append(s.operations, op)
+		}
+	}
+
+	// concrete paths match before templated ones, e.g. /pets/mine before /pets/{id}
+	sort.SliceStable(s.operations, func(i, j int) bool {
+		return len(s.operations[i].pathParams) < len(s.operations[j].pathParams)
+	})
+	return s, nil
+}
+
+/*
+This function returns an operation of the document
+
+- key: operationId, or method and path template, e.g. "GET /pets/{id}"
+
+returns: *Operation, and false when the document has no such operation
+*/
+func (s *Spec) Operation(key string) (*Operation, bool) {
+	op, ok := s.byKey[key]
+	return op, ok
+}
+
+/*
+This function returns the operation matching the method and path of a request
+
+Paths are matched as they are and without the path of the server URLs of the document,
+e.g. /v1/pets matches /pets when a server is https://api.example.com/v1.
+
+returns: *Operation, and false when no operation matches
+*/
+func (s *Spec) FindOperation(method string, path string) (*Operation, bool) {
+	for _, candidate := range s.candidatePaths(path) {
+		for _, op := range s.operations {
+			if op.Method == method && op.pattern.MatchString(candidate) {
+				return op, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s *Spec) candidatePaths(path string) []string {
+	candidates := []string{path}
+	for _, base := range s.basePaths {
+		if rest, ok := strings.CutPrefix(path, base); ok && (rest == "" || rest[0] == '/') {
+			if rest == "" {
+				rest = "/"
+			}
+			candidates = append(candidates, rest)
+		}
+	}
+	return candidates
+}
+
+// resolve follows a chain of "$ref"s to an object, returning it with its location
+func (s *Spec) resolve(node interface{}, pointer string) (map[string]interface{}, string, error) {
+	for seen := 0; ; seen++ {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("openapi: invalid document at %s: expected an object", pointer)
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, pointer, nil
+		}
+		if seen == 32 {
+			return nil, "", fmt.Errorf("openapi: invalid document at %s: too many nested references", pointer)
+		}
+		if !strings.HasPrefix(ref, "#") {
+			return nil, "", fmt.Errorf("openapi: invalid document at %s/$ref: only local references are supported, got %q", pointer, ref)
+		}
+		target, ok := resolvePointer(s.doc, strings.TrimPrefix(ref, "#"))
+		if !ok {
+			return nil, "", fmt.Errorf("openapi: invalid document at %s/$ref: unresolved reference %q", pointer, ref)
+		}
+		node, pointer = target, strings.TrimPrefix(ref, "#")
+	}
+}
+
+// basePaths returns the paths of the server URLs, e.g. "/v1" for https://api.example.com/v1
+func basePaths(doc map[string]interface{}) []string {
+	servers, _ := doc["servers"].([]interface{})
+	var paths []string
+	for _, server := range servers {
+		server, _ := server.(map[string]interface{})
+		raw, _ := server["url"].(string)
+		if strings.Contains(raw, "{") {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		if path := strings.TrimRight(u.Path, "/"); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// normalize turns a document decoded by yaml.v3 into the shape encoding/json produces
+func normalize(node interface{}) interface{} {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			node[key] = normalize(value)
+		}
+		return node
+	case map[interface{}]interface{}:
+		// YAML keys may be numbers, e.g. response codes
+		object := make(map[string]interface{}, len(node))
+		for key, value := range node {
+			object[fmt.Sprint(key)] = normalize(value)
+		}
+		return object
+	case []interface{}:
+		for i, value := range node {
+			node[i] = normalize(value)
+		}
+		return node
+	case int:
+		return json.Number(strconv.Itoa(node))
+	case uint64:
+		return json.Number(strconv.FormatUint(node, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(node, 'f', -1, 64))
+	case time.Time:
+		// unquoted dates are YAML timestamps, JSON Schema knows them as strings
+		if node.Equal(node.Truncate(24*time.Hour)) && node.Location() == time.UTC {
+			return node.Format(time.DateOnly)
+		}
+		return node.Format(time.RFC3339Nano)
+	}
+	return node
+}
+
+func resolvePointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = node[token]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			doc = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var templateParam = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// pathPattern turns a path template, e.g. /pets/{id}, into a regular expression capturing its parameters
+func pathPattern(template string) (*regexp.Regexp, []string) {
+	var b strings.Builder
+	var names []string
+	b.WriteByte('^')
+	last := 0
+	for _, match := range templateParam.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(regexp.QuoteMeta(template[last:match[0]]))
+		b.WriteString("([^/]+)")
+		names = append(names, template[match[2]:match[3]])
+		last = match[1]
+	}
+	b.WriteString(regexp.QuoteMeta(template[last:]))
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String()), names
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func loadPetstore(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Load("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func TestOperationLookup(t *testing.T) {
+	spec := loadPetstore(t)
+
+	tests := []struct {
+		key  string
+		path string
+	}{
+		{"listPets", "/pets"},
+		{"createPet", "/pets"},
+		{"GET /pets/{id}", "/pets/{id}"},
+		{"PUT /pets/{id}", "/pets/{id}"},
+	}
+	for _, tt := range tests {
+		op, ok := spec.Operation(tt.key)
+		if !ok || op.Path != tt.path {
+			t.Errorf("Operation(%q) = %v, %v; want path %s", tt.key, op, ok, tt.path)
+		}
+	}
+	if _, ok := spec.Operation("deletePet"); ok {
+		t.Errorf("Operation() should not find an undeclared operation")
+	}
+}
+
+func TestFindOperation(t *testing.T) {
+	spec := loadPetstore(t)
+
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/pets", "listPets"},
+		{"GET", "/pets/", "listPets"},
+		{"POST", "/pets", "createPet"},
+		{"GET", "/pets/mine", "listMyPets"},
+		{"GET", "/pets/42", "showPet"},
+		{"GET", "/v1/pets/42", "showPet"},
+		{"POST", "/pets/batch", "createPets"},
+		{"DELETE", "/pets/42", ""},
+		{"GET", "/owners", ""},
+		{"GET", "/v10/pets", ""},
+	}
+	for _, tt := range tests {
+		op, ok := spec.FindOperation(tt.method, tt.path)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("FindOperation(%s %s) = %s; want none", tt.method, tt.path, op.ID)
+			}
+			continue
+		}
+		if !ok || op.ID != tt.want {
+			t.Errorf("FindOperation(%s %s) = %v, %v; want %s", tt.method, tt.path, op, ok, tt.want)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	spec, err := Parse([]byte(`{
+		"openapi": "3.1.0",
+		"paths": {"/items/{sku}.json": {"get": {
+			"operationId": "getItem",
+			"parameters": [{"name": "sku", "in": "path", "required": true, "schema": {"pattern": "^[A-Z]+$"}}]
+		}}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, ok := spec.FindOperation("GET", "/items/ABC.json")
+	if !ok || op.ID != "getItem" {
+		t.Errorf("FindOperation() = %v, %v", op, ok)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{`openapi: 3.0.3`, `unsupported OpenAPI version "3.0.3"`},
+		{`[1, 2]`, "expected an object"},
+		{`openapi: 3.1.0
+paths: {"/a": {get: {parameters: [{$ref: "#/components/parameters/Missing"}]}}}`, `at /paths/~1a/get/parameters/0/$ref: unresolved reference`},
+		{`openapi: 3.1.0
+paths: {"/a": {get: {parameters: [{name: a, in: body}]}}}`, `at /paths/~1a/get/parameters/0/in: unknown parameter location "body"`},
+		{`openapi: 3.1.0
+paths: {"/a": {get: {parameters: [{name: a, in: query, schema: {minimum: x}}]}}}`, "invalid JSON Schema at /paths/~1a/get/parameters/0/schema/minimum"},
+		{`openapi: 3.1.0
+paths: {"/a": {post: {requestBody: {content: {application/json: {schema: {$ref: "#/components/schemas/A"}}}}}}}
+components: {schemas: {A: {type: object, properties: {b: {type: text}}}}}`, "invalid JSON Schema at /components/schemas/A/properties/b/type"},
+		{`openapi: 3.1.0
+paths: {"/a": {get: {operationId: x}, post: {operationId: x}}}`, `duplicate operationId "x"`},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.doc))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%s) = %v; want an error containing %q", tt.doc, err, tt.want)
+		}
+	}
+
+	if _, err := Load("testdata/missing.yaml"); err == nil {
+		t.Errorf("Load() should fail for a missing file")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	spec, err := Parse([]byte(`openapi: 3.1.0
+paths:
+  /a:
+    get:
+      parameters:
+        - name: day
+          in: query
+          schema:
+            enum: [2024-02-29, today]
+        - name: n
+          in: query
+          schema:
+            type: number
+            enum: [3, 2.5]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, _ := spec.Operation("GET /a")
+	for query, valid := range map[string]bool{
+		"day=2024-02-29": true,
+		"day=today":      true,
+		"day=2024-03-01": false,
+		"n=3":            true,
+		"n=2.5":          true,
+		"n=4":            false,
+	} {
+		errs, err := op.ValidateRequest(newRequest("GET", "/a?"+query, "", ""))
+		if err != nil || (errs == nil) != valid {
+			t.Errorf("%s: ValidateRequest() = %v, %v", query, errs, err)
+		}
+	}
+}
@@ -0,0 +1,531 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+var locations = []string{"path", "query", "header", "cookie"}
+
+/*
+Operation is an operation of the document, e.g. "GET /pets/{id}", safe for concurrent use
+*/
+type Operation struct {
+	ID     string // operationId, "" when the document does not give one
+	Method string // e.g. "GET"
+	Path   string // path template, e.g. "/pets/{id}"
+
+	spec       *Spec
+	pointer    string
+	pattern    *regexp.Regexp
+	pathParams []string
+	params     map[string][]*parameter
+	schemas    map[string]*validator.Schema
+	body       *requestBody
+}
+
+// parameter is what is needed to turn the raw value of a parameter into the JSON value its schema describes
+type parameter struct {
+	name     string
+	style    string
+	explode  bool
+	typ      string // JSON type of the schema, "" when unknown
+	itemType string // JSON type of the items of an array
+}
+
+type requestBody struct {
+	required bool
+	media    []bodyContent
+}
+
+type bodyContent struct {
+	mediaRange string
+	field      *validator.SchemaField // nil when the media type has no schema
+}
+
+func (s *Spec) compileOperation(template string, method string, item map[string]interface{}, itemPointer string) (*Operation, error) {
+	pointer := itemPointer + "/" + strings.ToLower(method)
+	node, ok := item[strings.ToLower(method)].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("openapi: invalid document at %s: expected an object", pointer)
+	}
+
+	op := &Operation{
+		Method:  method,
+		Path:    template,
+		spec:    s,
+		pointer: pointer,
+		params:  map[string][]*parameter{},
+		schemas: map[string]*validator.Schema{},
+	}
+	op.ID, _ = node["operationId"].(string)
+	op.pattern, op.pathParams = pathPattern(template)
+
+	// parameters of the operation override the ones of the path item with the same name and location
+	type located struct {
+		node    map[string]interface{}
+		pointer string
+	}
+	declared := map[string]located{}
+	var order []string
+	for _, level := range []struct {
+		node    map[string]interface{}
+		pointer string
+	}{{item, itemPointer}, {node, pointer}} {
+		list, ok := level.node["parameters"]
+		if !ok {
+			continue
+		}
+		params, ok := list.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("openapi: invalid document at %s/parameters: expected an array", level.pointer)
+		}
+		for i, param := range params {
+			resolved, resolvedPointer, err := s.resolve(param, level.pointer+"/parameters/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			name, _ := resolved["name"].(string)
+			in, _ := resolved["in"].(string)
+			if name == "" {
+				return nil, fmt.Errorf("openapi: invalid document at %s/name: expected a parameter name", resolvedPointer)
+			}
+			switch in {
+			case "path", "query", "header", "cookie":
+			default:
+				return nil, fmt.Errorf("openapi: invalid document at %s/in: unknown parameter location %q", resolvedPointer, in)
+			}
+			key := in + " " + name
+			if _, ok := declared[key]; !ok {
+				order = append(order, key)
+			}
+			declared[key] = located{resolved, resolvedPointer}
+		}
+	}
+
+	fields := map[string][]*validator.SchemaField{}
+	for _, key := range order {
+		param := declared[key]
+		in, name := param.node["in"].(string), param.node["name"].(string)
+		if in == "header" && isReservedHeader(name) {
+			// described by the document for completeness, the spec says to ignore them
+			continue
+		}
+		field, p, err := s.compileParameter(name, in, param.node, param.pointer)
+		if err != nil {
+			return nil, err
+		}
+		fields[in] = append(fields[in], field)
+		op.params[in] = append(op.params[in], p)
+	}
+	for _, in := range locations {
+		if len(fields[in]) > 0 {
+			op.schemas[in] = validator.NewSchema(fields[in]...)
+		}
+	}
+
+	if body, ok := node["requestBody"]; ok {
+		var err error
+		if op.body, err = s.compileRequestBody(body, pointer+"/requestBody"); err != nil {
+			return nil, err
+		}
+	}
+	return op, nil
+}
+
+func (s *Spec) compileParameter(name string, in string, node map[string]interface{}, pointer string) (*validator.SchemaField, *parameter, error) {
+	p := &parameter{name: name, style: "simple"}
+	if in == "query" || in == "cookie" {
+		p.style = "form"
+	}
+	if style, ok := node["style"].(string); ok {
+		p.style = style
+	}
+	p.explode = p.style == "form"
+	if explode, ok := node["explode"].(bool); ok {
+		p.explode = explode
+	}
+
+	field := validator.Field(name)
+	if _, ok := node["schema"]; ok {
+		var err error
+		if field, err = validator.CompileJSONSchemaField(name, s.doc, pointer+"/schema"); err != nil {
+			return nil, nil, err
+		}
+		p.typ = s.schemaType(node["schema"])
+		if p.typ == "array" {
+			if schema, ok := s.resolveSchema(node["schema"]); ok {
+				p.itemType = s.schemaType(schema["items"])
+			}
+		}
+	}
+
+	// path parameters are always required
+	required, _ := node["required"].(bool)
+	if !required && in != "path" {
+		field = field.NotRequired()
+	}
+	return field, p, nil
+}
+
+func (s *Spec) compileRequestBody(node interface{}, pointer string) (*requestBody, error) {
+	resolved, resolvedPointer, err := s.resolve(node, pointer)
+	if err != nil {
+		return nil, err
+	}
+	body := &requestBody{}
+	body.required, _ = resolved["required"].(bool)
+
+	content, _ := resolved["content"].(map[string]interface{})
+	for _, mediaRange := range sortedKeys(content) {
+		media := bodyContent{mediaRange: strings.ToLower(mediaRange)}
+		mediaNode, _ := content[mediaRange].(map[string]interface{})
+		if _, ok := mediaNode["schema"]; ok {
+			schemaPointer := resolvedPointer + "/content/" + escapePointer(mediaRange) + "/schema"
+			if media.field, err = validator.CompileJSONSchemaField("body", s.doc, schemaPointer); err != nil {
+				return nil, err
+			}
+			if !body.required {
+				media.field = media.field.NotRequired()
+			}
+		}
+		body.media = append(body.media, media)
+	}
+	return body, nil
+}
+
+// resolveSchema follows the "$ref"s of a schema, ok is false when it is a boolean schema or a reference is broken
+func (s *Spec) resolveSchema(schema interface{}) (map[string]interface{}, bool) {
+	for i := 0; i < 32; i++ {
+		object, ok := schema.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return object, true
+		}
+		if schema, ok = resolvePointer(s.doc, strings.TrimPrefix(ref, "#")); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// schemaType returns the type a raw parameter value is converted to, the first one that is not null
+func (s *Spec) schemaType(schema interface{}) string {
+	object, ok := s.resolveSchema(schema)
+	if !ok {
+		return ""
+	}
+	switch types := object["type"].(type) {
+	case string:
+		return types
+	case []interface{}:
+		for _, t := range types {
+			if t, ok := t.(string); ok && t != "null" {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+func isReservedHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Accept", "Content-Type", "Authorization":
+		return true
+	}
+	return false
+}
+
+/*
+This function validates a request against the operation matching its method and path, see Operation.ValidateRequest()
+
+returns: validator.ValidationErrors, nil when the request is valid, and ErrNoOperation when no operation matches
+*/
+func (s *Spec) ValidateRequest(r *http.Request) (validator.ValidationErrors, error) {
+	op, ok := s.FindOperation(r.Method, r.URL.Path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoOperation, r.Method, r.URL.Path)
+	}
+	return op.ValidateRequest(r)
+}
+
+/*
+This function validates the parameters and the body of a request against the operation
+
+- r: the request, its body is read and replaced so that handlers can still read it
+
+returns: validator.ValidationErrors, nil when the request is valid, and an error when it cannot be validated:
+ErrBodyTooLarge for a body over Spec.MaxBodyBytes, ErrUnsupportedMediaType for a body of a content type the
+operation does not accept, or an invalid JSON body
+
+Raw parameter values are converted to the type of their schema first, e.g. "?limit=10" to the integer 10,
+arrays following the style and explode settings of the parameter. A value that cannot be converted is
+validated as a string and fails the type check. Errors are reported with the name of the parameter, and
+with the path of the value in the body, e.g. "address.city", or "body" for the body as a whole.
+
+Path parameters are taken from the URL path, or from r.PathValue() when it does not match the path template.
+Bodies are validated when their media type is JSON, i.e. application/json or +json, other media types are only
+checked against the ones the operation accepts.
+*/
+func (o *Operation) ValidateRequest(r *http.Request) (validator.ValidationErrors, error) {
+	var errs validator.ValidationErrors
+	for _, in := range locations {
+		schema, ok := o.schemas[in]
+		if !ok {
+			continue
+		}
+		locationErrs, err := schema.ValidateCtx(r.Context(), o.values(r, in))
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, locationErrs...)
+	}
+
+	if o.body != nil {
+		bodyErrs, err := o.validateBody(r)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, bodyErrs...)
+	}
+
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return errs, nil
+}
+
+// values collects the parameters of a location, converted to the type of their schema
+func (o *Operation) values(r *http.Request, in string) map[string]interface{} {
+	values := map[string]interface{}{}
+	var pathValues map[string]string
+	if in == "path" {
+		pathValues = o.pathValues(r)
+	}
+
+	for _, p := range o.params[in] {
+		var raw []string
+		switch in {
+		case "path":
+			value, ok := pathValues[p.name]
+			if !ok {
+				value = r.PathValue(p.name)
+			}
+			if value != "" {
+				raw = []string{value}
+			}
+		case "query":
+			raw = r.URL.Query()[p.name]
+		case "header":
+			raw = r.Header.Values(p.name)
+		case "cookie":
+			if cookie, err := r.Cookie(p.name); err == nil {
+				raw = []string{cookie.Value}
+			}
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		values[p.name] = p.convert(raw)
+	}
+	return values
+}
+
+// pathValues extracts the path parameters from the URL path, nil when it does not match the template
+func (o *Operation) pathValues(r *http.Request) map[string]string {
+	for _, candidate := range o.spec.candidatePaths(r.URL.EscapedPath()) {
+		match := o.pattern.FindStringSubmatch(candidate)
+		if match == nil {
+			continue
+		}
+		values := make(map[string]string, len(o.pathParams))
+		for i, name := range o.pathParams {
+			value, err := url.PathUnescape(match[i+1])
+			if err != nil {
+				value = match[i+1]
+			}
+			values[name] = value
+		}
+		return values
+	}
+	return nil
+}
+
+// readBody reads the request body up to the size limit of the document, replacing it so that handlers can still read it
+func (o *Operation) readBody(r *http.Request) ([]byte, error) {
+	limit := o.spec.MaxBodyBytes
+	if limit == 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	if limit < 0 {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, readError(err)
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		return data, nil
+	}
+
+	// one byte more than the limit tells a body at the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return nil, readError(err)
+	}
+	if int64(len(data)) > limit {
+		// the part already read is put back in front of the rest of the body
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func readError(err error) error {
+	// set by http.MaxBytesReader when the server limits the body itself
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytes.Limit)
+	}
+	return fmt.Errorf("openapi: reading request body: %w", err)
+}
+
+// convert turns the raw values of a parameter into the JSON value its schema describes
+func (p *parameter) convert(raw []string) interface{} {
+	if p.typ != "array" {
+		return convertScalar(raw[0], p.typ)
+	}
+
+	items := raw
+	if !p.explode || p.style != "form" {
+		separator := ","
+		switch p.style {
+		case "spaceDelimited":
+			separator = " "
+		case "pipeDelimited":
+			separator = "|"
+		}
+		items = nil
+		for _, value := range raw {
+			items = append(items, strings.Split(value, separator)...)
+		}
+	}
+
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		values[i] = convertScalar(strings.TrimSpace(item), p.itemType)
+	}
+	return values
+}
+
+func convertScalar(raw string, typ string) interface{} {
+	switch typ {
+	case "integer", "number":
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		switch raw {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return raw
+}
+
+func (o *Operation) validateBody(r *http.Request) (validator.ValidationErrors, error) {
+	var data []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if data, err = o.readBody(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(data) == 0 {
+		if !o.body.required {
+			return nil, nil
+		}
+		return validator.NewSchema(validator.Field("body")).Validate(map[string]interface{}{}), nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+	media, ok := o.body.match(mediaType)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedMediaType, contentType)
+	}
+	if media.field == nil || !isJSON(mediaType) {
+		return nil, nil
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("openapi: invalid JSON body: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("openapi: invalid JSON body: unexpected data after the value")
+	}
+
+	errs, err := validator.NewSchema(media.field).ValidateCtx(r.Context(), map[string]interface{}{"body": doc})
+	if err != nil {
+		return nil, err
+	}
+	// the properties of an object body are reported as top-level fields
+	for i, e := range errs {
+		if rest, ok := strings.CutPrefix(e.Field, "body."); ok {
+			errs[i].Field = rest
+			if len(e.Path) > 0 {
+				errs[i].Path = e.Path[1:]
+			}
+		}
+	}
+	return errs, nil
+}
+
+// match returns the media type of the body accepting mediaType, the most specific one first
+func (b *requestBody) match(mediaType string) (bodyContent, bool) {
+	if mediaType != "" {
+		major, _, _ := strings.Cut(mediaType, "/")
+		for _, candidate := range []string{mediaType, major + "/*", "*/*"} {
+			for _, media := range b.media {
+				if media.mediaRange == candidate {
+					return media, true
+				}
+			}
+		}
+	}
+	return bodyContent{}, false
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	validator "github.com/mcctrix/ctrix-validator"
+)
+
+func newRequest(method string, target string, contentType string, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body == "" {
+		r = httptest.NewRequest(method, target, nil)
+	}
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+// codes maps the field of every error to its code
+func codes(errs validator.ValidationErrors) map[string]string {
+	got := map[string]string{}
+	for _, err := range errs {
+		got[err.Field] = err.Code
+	}
+	return got
+}
+
+func TestValidateParameters(t *testing.T) {
+	spec := loadPetstore(t)
+
+	tests := []struct {
+		name   string
+		target string
+		header map[string]string
+		want   map[string]string
+	}{
+		{
+			name:   "valid",
+			target: "/pets?limit=10&tags=ab&tags=cd&ids=1,2",
+			header: map[string]string{"X-Request-Id": "https://example.com/requests/1", "Cookie": "session=abcdefgh"},
+			want:   map[string]string{},
+		},
+		{
+			name:   "missing required header",
+			target: "/pets",
+			want:   map[string]string{"X-Request-Id": validator.CodeRequired},
+		},
+		{
+			name:   "wrong types and bounds",
+			target: "/v1/pets?limit=abc&tags=ab&tags=c&ids=1,0",
+			header: map[string]string{"X-Request-Id": "not a uri", "Cookie": "session=short"},
+			want: map[string]string{
+				"limit":        validator.CodeTypeMismatch,
+				"tags[1]":      validator.CodeMin,
+				"ids[1]":       validator.CodeMin,
+				"X-Request-Id": validator.CodeUrl,
+				"session":      validator.CodeMin,
+			},
+		},
+		{
+			name:   "maximum",
+			target: "/pets?limit=500",
+			header: map[string]string{"X-Request-Id": "urn:uuid:6e8bc430-9c3a-11d9-9669-0800200c9a66"},
+			want:   map[string]string{"limit": validator.CodeMax},
+		},
+		{
+			name:   "path parameter",
+			target: "/pets/0?verbose=yes",
+			want:   map[string]string{"id": validator.CodeMin, "verbose": validator.CodeTypeMismatch},
+		},
+		{
+			name:   "path parameter type",
+			target: "/pets/rex?verbose=true",
+			want:   map[string]string{"id": validator.CodeTypeMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest("GET", tt.target, "", "")
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			errs, err := spec.ValidateRequest(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := codes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRequest() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateParameterMessage(t *testing.T) {
+	spec := loadPetstore(t)
+	errs, _ := spec.ValidateRequest(newRequest("GET", "/pets/1.5", "", ""))
+	if len(errs) != 1 || errs[0].Message != "must be of type integer, got number" {
+		t.Errorf("ValidateRequest() = %v", errs)
+	}
+}
+
+func TestValidatePathValue(t *testing.T) {
+	spec := loadPetstore(t)
+	op, _ := spec.Operation("showPet")
+
+	// the route of the mux differs from the template, the parameter is taken from PathValue
+	var errs validator.ValidationErrors
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /animals/{id}", func(w http.ResponseWriter, r *http.Request) {
+		errs, _ = op.ValidateRequest(r)
+	})
+	mux.ServeHTTP(httptest.NewRecorder(), newRequest("GET", "/animals/-3", "", ""))
+	if got := codes(errs); !reflect.DeepEqual(got, map[string]string{"id": validator.CodeMin}) {
+		t.Errorf("ValidateRequest() = %v", got)
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	spec := loadPetstore(t)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want map[string]string
+	}{
+		{
+			name: "valid",
+			path: "/pets",
+			body: `{"name": "Rex", "kind": "dog", "born": "2020-01-31", "owner": {"email": "ann@example.com"}}`,
+			want: map[string]string{},
+		},
+		{
+			name: "null and empty nullable property",
+			path: "/pets",
+			body: `{"name": "Rex", "kind": "dog", "nickname": null, "parent": {"name": "Max", "kind": "dog", "nickname": ""}}`,
+			want: map[string]string{},
+		},
+		{
+			name: "invalid properties",
+			path: "/pets",
+			body: `{"name": "R", "kind": "bird", "owner": {"email": "ann"}, "parent": {"name": "Max"}}`,
+			want: map[string]string{
+				"name":        validator.CodeMin,
+				"kind":        validator.CodeEnum,
+				"owner.email": validator.CodeEmail,
+				"parent.kind": validator.CodeRequired,
+			},
+		},
+		{
+			name: "missing body",
+			path: "/pets",
+			want: map[string]string{"body": validator.CodeRequired},
+		},
+		{
+			name: "array body",
+			path: "/pets/batch",
+			body: `[{"name": "Rex", "kind": "dog"}, {"kind": "cat"}]`,
+			want: map[string]string{"body[1].name": validator.CodeRequired},
+		},
+		{
+			name: "empty array body",
+			path: "/pets/batch",
+			body: `[]`,
+			want: map[string]string{"body": validator.CodeMin},
+		},
+		{
+			name: "wrong body type",
+			path: "/pets",
+			body: `["Rex"]`,
+			want: map[string]string{"body": validator.CodeTypeMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest("POST", tt.path, "application/json; charset=utf-8", tt.body)
+			errs, err := spec.ValidateRequest(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := codes(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRequest() = %v; want %v", got, tt.want)
+			}
+
+			// the handler can still read the body
+			data, _ := io.ReadAll(r.Body)
+			if string(data) != tt.body {
+				t.Errorf("body after validation = %q; want %q", data, tt.body)
+			}
+		})
+	}
+}
+
+func TestValidateBodyLimit(t *testing.T) {
+	spec := loadPetstore(t)
+	spec.MaxBodyBytes = 32
+	body := `{"name": "Rex", "kind": "dog", "born": "2020-01-31"}`
+
+	r := newRequest("POST", "/pets", "application/json", body)
+	if _, err := spec.ValidateRequest(r); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("ValidateRequest() error = %v; want ErrBodyTooLarge", err)
+	}
+	if data, _ := io.ReadAll(r.Body); string(data) != body {
+		t.Errorf("body after a rejected request = %q; want %q", data, body)
+	}
+
+	r = newRequest("POST", "/pets", "application/json", body)
+	r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 16)
+	spec.MaxBodyBytes = -1
+	if _, err := spec.ValidateRequest(r); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("ValidateRequest() with http.MaxBytesReader error = %v; want ErrBodyTooLarge", err)
+	}
+
+	spec.MaxBodyBytes = int64(len(body))
+	if errs, err := spec.ValidateRequest(newRequest("POST", "/pets", "application/json", body)); errs != nil || err != nil {
+		t.Errorf("ValidateRequest() of a body at the limit = %v, %v", errs, err)
+	}
+}
+
+func TestValidateBodyPointer(t *testing.T) {
+	spec := loadPetstore(t)
+	errs, _ := spec.ValidateRequest(newRequest("POST", "/pets", "application/json", `{"name": "Rex", "kind": "dog", "owner": {}}`))
+	if len(errs) != 1 || errs[0].Pointer() != "/owner/email" {
+		t.Errorf("ValidateRequest() = %#v", errs)
+	}
+}
+
+func TestValidateBodyMediaTypes(t *testing.T) {
+	spec := loadPetstore(t)
+
+	errs, err := spec.ValidateRequest(newRequest("PUT", "/pets/1", "application/merge-patch+json", `{"name": 5}`))
+	if err != nil || codes(errs)["name"] != validator.CodeTypeMismatch {
+		t.Errorf("+json body: ValidateRequest() = %v, %v", errs, err)
+	}
+
+	// optional body and media type without schema
+	for _, r := range []*http.Request{
+		newRequest("PUT", "/pets/1", "", ""),
+		newRequest("PUT", "/pets/1", "text/plain", "anything"),
+	} {
+		if errs, err := spec.ValidateRequest(r); errs != nil || err != nil {
+			t.Errorf("ValidateRequest() = %v, %v", errs, err)
+		}
+	}
+
+	for _, contentType := range []string{"text/xml", ""} {
+		_, err := spec.ValidateRequest(newRequest("POST", "/pets", contentType, `{}`))
+		if !errors.Is(err, ErrUnsupportedMediaType) {
+			t.Errorf("Content-Type %q: ValidateRequest() error = %v; want ErrUnsupportedMediaType", contentType, err)
+		}
+	}
+
+	for _, body := range []string{`{"name": `, `{} {}`} {
+		if _, err := spec.ValidateRequest(newRequest("POST", "/pets", "application/json", body)); err == nil {
+			t.Errorf("ValidateRequest(%s) should fail on invalid JSON", body)
+		}
+	}
+
+	if _, err := spec.ValidateRequest(newRequest("PATCH", "/pets/1", "", "")); !errors.Is(err, ErrNoOperation) {
+		t.Errorf("ValidateRequest() error = %v; want ErrNoOperation", err)
+	}
+}
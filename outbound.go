@@ -0,0 +1,240 @@
+package validator
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Error codes of SafeOutboundURL()
+const (
+	CodeUnsafeURL       = "unsafe_url"
+	CodeUrlUnresolvable = "url_unresolvable"
+)
+
+/*
+Resolver looks up the ip addresses of a host for SafeOutboundURL(), *net.Resolver implements it
+
+Tests inject a stub returning fixed addresses:
+
+	type stubResolver map[string][]netip.Addr
+
+	func (r stubResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	    return r[host], nil
+	}
+*/
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network string, host string) ([]netip.Addr, error)
+}
+
+// metadataAddrs are the instance metadata endpoints of the cloud providers
+var metadataAddrs = []netip.Addr{
+	netip.MustParseAddr("169.254.169.254"), // AWS, GCP, Azure, DigitalOcean, ...
+	netip.MustParseAddr("169.254.170.2"),   // AWS ECS task metadata
+	netip.MustParseAddr("100.100.100.200"), // Alibaba Cloud
+	netip.MustParseAddr("192.0.0.192"),     // Oracle Cloud
+	netip.MustParseAddr("fd00:ec2::254"),   // AWS IPv6
+}
+
+// nat64Prefix embeds IPv4 addresses in IPv6 ones, 64:ff9b::10.0.0.1 reaches 10.0.0.1 through a NAT64 gateway
+var nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+/*
+This function sets the resolver of SafeOutboundURL(), for the rest of the validator
+
+- r: looks up the addresses of a host, net.DefaultResolver by default
+
+returns: *Validator
+*/
+func (v *Validator) Resolver(r Resolver) *Validator {
+	v.resolver = r
+	return v
+}
+
+/*
+This function checks if the field is a url that is safe to request from the server, i.e. one that
+does not point to internal infrastructure (SSRF)
+
+- policies: further restrictions, see Url(), http and https urls are accepted when none is given
+
+returns: *Validator
+
+The host is rejected with the unsafe_url code when it is, or resolves to, a loopback, link-local, private,
+multicast, unspecified, cloud metadata (e.g. 169.254.169.254) or other non-public address. The error
+reports the address in Params["address"] and its class in Params["reason"]. IP literals are also read
+in the decimal, octal and hex forms HTTP clients accept, e.g. http://2130706433/ or http://0x7f.1/.
+
+Note: Hosts are resolved by a CustomCtx() rule, so only when ValidateCtx() is called, GetError() reports
+the field as unchecked until then. Schema.Validate() and ValidateStruct() run it with context.Background(),
+use their ValidateCtx() to bound the lookup with a deadline. The check does not protect against a host
+resolving to another address when the url is requested (DNS rebinding), dial the validated address or
+check it again in the dialer.
+
+Example:
+
+	validator := NewValidator("webhook", data.Webhook).SafeOutboundURL(URLPolicy{Schemes: []string{"https"}})
+	if err := validator.ValidateCtx(ctx); err != nil {
+	    return err
+	}
+	errs := validator.GetError()
+*/
+func (v *Validator) SafeOutboundURL(policies ...URLPolicy) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	u, raw, ok := v.urlValue()
+	if !ok {
+		return v
+	}
+	if len(policies) == 0 {
+		policies = []URLPolicy{{Schemes: []string{"http", "https"}}}
+	}
+	v.checkURLPolicies(&u, raw, policies)
+	if v.fieldFailed() {
+		return v
+	}
+
+	host := u.Hostname()
+	if addr, ok := parseHostIP(host); ok {
+		if err, unsafe := unsafeAddress(host, addr); unsafe {
+			err.Field = v.fieldName
+			err.Value = v.data
+			v.appendError(err)
+		}
+		return v
+	}
+
+	resolver := v.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return v.CustomCtx(func(ctx context.Context, value interface{}) error {
+		addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil || len(addrs) == 0 {
+			return ValidationError{
+				Code:    CodeUrlUnresolvable,
+				Message: "must point to a resolvable host",
+				Params:  map[string]interface{}{"host": host},
+			}
+		}
+		for _, addr := range addrs {
+			if err, unsafe := unsafeAddress(host, addr); unsafe {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// unsafeAddress returns the unsafe_url error of an address host must not point to
+func unsafeAddress(host string, addr netip.Addr) (ValidationError, bool) {
+	reason := addressClass(addr)
+	if reason == "" {
+		return ValidationError{}, false
+	}
+	return ValidationError{
+		Code:    CodeUnsafeURL,
+		Message: "must not point to the internal address " + addr.String(),
+		Params:  map[string]interface{}{"host": host, "address": addr.String(), "reason": reason},
+	}, true
+}
+
+// addressClass returns why addr is not safe to request, e.g. "loopback" or "metadata", "" for public addresses
+func addressClass(addr netip.Addr) string {
+	addr = addr.Unmap().WithZone("")
+	if nat64Prefix.Contains(addr) {
+		b := addr.As16()
+		return addressClass(netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]}))
+	}
+	for _, metadata := range metadataAddrs {
+		if addr == metadata {
+			return "metadata"
+		}
+	}
+	switch {
+	case addr.IsLoopback():
+		return "loopback"
+	case addr.IsLinkLocalUnicast(), addr.IsLinkLocalMulticast():
+		return "link_local"
+	case addr.IsPrivate():
+		return "private"
+	case addr.IsMulticast():
+		return "multicast"
+	case addr.IsUnspecified():
+		return "unspecified"
+	case !isPublicIP(addr):
+		return "reserved"
+	}
+	return ""
+}
+
+/*
+parseHostIP reads the ip address a url host stands for, including the IPv4 forms of inet_aton(3)
+that HTTP clients accept: 2130706433, 0177.0.0.1, 0x7f.0.0.1 and 127.1 are all 127.0.0.1
+*/
+func parseHostIP(host string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return addr, true
+	}
+
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+	var ip uint64
+	for i, part := range parts {
+		base, digits := 10, part
+		switch {
+		case len(part) > 2 && (part[:2] == "0x" || part[:2] == "0X"):
+			base, digits = 16, part[2:]
+		case len(part) > 1 && part[0] == '0':
+			base, digits = 8, part[1:]
+		}
+		n, err := strconv.ParseUint(digits, base, 32)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		// the last part fills the remaining bytes, e.g. the 3 lower bytes in 127.1
+		bits := 8
+		if i == len(parts)-1 {
+			bits = 8 * (4 - i)
+		}
+		if n >= 1<<bits {
+			return netip.Addr{}, false
+		}
+		ip = ip<<bits | n
+	}
+	return netip.AddrFrom4([4]byte{byte(ip >> 24), byte(ip >> 16), byte(ip >> 8), byte(ip)}), true
+}
+
+/*
+This function checks if the field is a url that is safe to request from the server, see Validator.SafeOutboundURL()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) SafeOutboundURL(policies ...URLPolicy) *SchemaField {
+	args := make([]interface{}, len(policies))
+	for i, policy := range policies {
+		args[i] = policy
+	}
+	return f.with(schemaRule{code: CodeUnsafeURL, args: args, apply: func(v *Validator) *Validator {
+		return v.SafeOutboundURL(policies...)
+	}})
+}
+
+/*
+This function sets the resolver of the SafeOutboundURL() rules, see Validator.Resolver()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) Resolver(r Resolver) *Schema {
+	schema := *s
+	schema.resolver = r
+	return &schema
+}
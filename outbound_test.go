@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stubResolver resolves the hosts it lists, and fails for the others
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupNetIP(ctx context.Context, network string, host string) ([]netip.Addr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]netip.Addr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = netip.MustParseAddr(ip)
+	}
+	return addrs, nil
+}
+
+// blockingResolver answers once the context ends, like a lookup that hangs
+type blockingResolver struct{}
+
+func (blockingResolver) LookupNetIP(ctx context.Context, network string, host string) ([]netip.Addr, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+var testResolver = stubResolver{
+	"example.com":          {"93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c"},
+	"internal.example.com": {"93.184.215.14", "10.0.0.7"},
+	"localhost":            {"127.0.0.1", "::1"},
+	"metadata.example.com": {"169.254.169.254"},
+}
+
+func TestParseHostIP(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"127.0.0.1", "127.0.0.1"},
+		{"2130706433", "127.0.0.1"},
+		{"0177.0.0.1", "127.0.0.1"},
+		{"0x7f.0.0.1", "127.0.0.1"},
+		{"0x7F000001", "127.0.0.1"},
+		{"127.1", "127.0.0.1"},
+		{"10.1.258", "10.1.1.2"},
+		{"0251.0376.0251.0376", "169.254.169.254"},
+		{"::1", "::1"},
+		{"example.com", ""},
+		{"4294967296", ""},
+		{"1.2.3.256", ""},
+		{"1.2.3.4.5", ""},
+		{"08.0.0.1", ""},
+		{"0x", ""},
+		{"1..1", ""},
+	}
+
+	for _, tt := range tests {
+		addr, ok := parseHostIP(tt.host)
+		got := ""
+		if ok {
+			got = addr.String()
+		}
+		if got != tt.want {
+			t.Errorf("parseHostIP(%q) = %q; want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestSafeOutboundURL(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   []string
+		reason string
+	}{
+		{"https://example.com/hooks", []string{}, ""},
+		{"https://93.184.215.14/hooks", []string{}, ""},
+		{"http://localhost:8080/", []string{"url:unsafe_url"}, "loopback"},
+		{"https://internal.example.com/", []string{"url:unsafe_url"}, "private"},
+		{"http://metadata.example.com/latest/meta-data", []string{"url:unsafe_url"}, "metadata"},
+		{"http://169.254.169.254/latest/meta-data", []string{"url:unsafe_url"}, "metadata"},
+		{"http://2130706433/", []string{"url:unsafe_url"}, "loopback"},
+		{"http://0x7f.1/", []string{"url:unsafe_url"}, "loopback"},
+		{"http://0177.0.0.1/", []string{"url:unsafe_url"}, "loopback"},
+		{"http://[::ffff:10.0.0.1]/", []string{"url:unsafe_url"}, "private"},
+		{"http://[64:ff9b::a9fe:a9fe]/", []string{"url:unsafe_url"}, "metadata"},
+		{"http://[fe80::1%25eth0]/", []string{"url:unsafe_url"}, "link_local"},
+		{"http://239.1.2.3/", []string{"url:unsafe_url"}, "multicast"},
+		{"http://0.0.0.0/", []string{"url:unsafe_url"}, "unspecified"},
+		{"http://100.64.0.1/", []string{"url:unsafe_url"}, "reserved"},
+		{"https://unknown.example.com/", []string{"url:url_unresolvable"}, ""},
+		{"gopher://example.com/", []string{"url:url_scheme"}, ""},
+		{"not a url", []string{"url:url"}, ""},
+	}
+
+	for _, tt := range tests {
+		v := NewValidator("url", tt.url).Resolver(testResolver).SafeOutboundURL()
+		if err := v.ValidateCtx(context.Background()); err != nil {
+			t.Fatalf("ValidateCtx() = %v", err)
+		}
+		errs := v.GetError()
+		if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SafeOutboundURL() on %q = %v; want %v", tt.url, got, tt.want)
+			continue
+		}
+		if tt.reason != "" && errs[0].Params["reason"] != tt.reason {
+			t.Errorf("SafeOutboundURL() on %q reason = %v; want %s", tt.url, errs[0].Params["reason"], tt.reason)
+		}
+	}
+}
+
+func TestSafeOutboundURLAddress(t *testing.T) {
+	v := NewValidator("webhook", "https://internal.example.com/hook").Resolver(testResolver).SafeOutboundURL()
+	v.ValidateCtx(context.Background())
+	errs := v.GetError()
+	if len(errs) != 1 {
+		t.Fatalf("GetError() = %v", errs)
+	}
+	want := map[string]interface{}{"host": "internal.example.com", "address": "10.0.0.7", "reason": "private"}
+	if !reflect.DeepEqual(errs[0].Params, want) {
+		t.Errorf("Params = %v; want %v", errs[0].Params, want)
+	}
+	if errs[0].Message != "must not point to the internal address 10.0.0.7" {
+		t.Errorf("Message = %q", errs[0].Message)
+	}
+}
+
+func TestSafeOutboundURLPolicy(t *testing.T) {
+	https := URLPolicy{Schemes: []string{"https"}, ForbidCredentials: true}
+	v := NewValidator("webhook", "http://example.com/").Resolver(testResolver).SafeOutboundURL(https)
+	v.NextField("other", "https://user@example.com/").SafeOutboundURL(https)
+	v.ValidateCtx(context.Background())
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"webhook:url_scheme", "other:url_credentials"}) {
+		t.Errorf("GetError() = %v", got)
+	}
+}
+
+func TestSafeOutboundURLSchema(t *testing.T) {
+	schema := NewSchema(
+		Field("webhook").SafeOutboundURL(),
+		Field("fallback").NotRequired().SafeOutboundURL(),
+	).Resolver(testResolver)
+
+	errs, err := schema.ValidateCtx(context.Background(), map[string]interface{}{
+		"webhook":  "https://localhost/hook",
+		"fallback": "https://example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("ValidateCtx() = %v", err)
+	}
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"webhook:unsafe_url"}) {
+		t.Errorf("ValidateCtx() = %v", got)
+	}
+
+	// a cancelled lookup leaves the url unchecked, never valid
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs, err = schema.ValidateCtx(ctx, map[string]interface{}{"webhook": "https://example.com/hook"})
+	if got := errorCodes(errs); !errors.Is(err, context.Canceled) || !reflect.DeepEqual(got, []string{"webhook:unchecked"}) {
+		t.Errorf("ValidateCtx() with a cancelled context = %v, %v", got, err)
+	}
+}
+
+func TestSafeOutboundURLDeadline(t *testing.T) {
+	v := NewValidator("webhook", "http://internal.corp/").Resolver(blockingResolver{}).SafeOutboundURL()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := v.ValidateCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ValidateCtx() = %v; want context.DeadlineExceeded", err)
+	}
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"webhook:unchecked"}) {
+		t.Errorf("GetError() after an interrupted lookup = %v; want the url reported unchecked", got)
+	}
+
+	// the same through the alternatives of a rule string
+	schema := NewSchema(MustParseRules("contact", "safe_outbound_url|email")).Resolver(blockingResolver{})
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	errs, err := schema.ValidateCtx(ctx, map[string]interface{}{"contact": "http://internal.corp/"})
+	if got := errorCodes(errs); !errors.Is(err, context.DeadlineExceeded) || !reflect.DeepEqual(got, []string{"contact:unchecked"}) {
+		t.Errorf("safe_outbound_url|email with an interrupted lookup = %v, %v", got, err)
+	}
+}
+
+func TestSafeOutboundURLRule(t *testing.T) {
+	type hook struct {
+		URL string `json:"url" validate:"safe_outbound_url"`
+	}
+	errs := ValidateStruct(hook{URL: "http://127.0.0.1/"})
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"url:unsafe_url"}) {
+		t.Errorf("ValidateStruct() = %v", got)
+	}
+
+	// host names are resolved too, localhost by the hosts file of the system resolver
+	errs = ValidateStruct(hook{URL: "http://localhost:8080/"})
+	if got := errorCodes(errs); !reflect.DeepEqual(got, []string{"url:unsafe_url"}) {
+		t.Errorf("ValidateStruct() on a host name = %v", got)
+	}
+
+	errs, err := StructSchema(reflect.TypeOf(hook{})).Resolver(testResolver).ValidateCtx(context.Background(), hook{URL: "https://internal.example.com/"})
+	if got := errorCodes(errs); err != nil || !reflect.DeepEqual(got, []string{"url:unsafe_url"}) {
+		t.Errorf("StructSchema().ValidateCtx() = %v, %v", got, err)
+	}
+}
+
+func TestSafeOutboundURLAlternative(t *testing.T) {
+	schema := NewSchema(MustParseRules("contact", "safe_outbound_url|email")).Resolver(testResolver)
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"https://example.com/contact", []string{}},
+		{"ann@example.com", []string{}},
+		{"https://internal.example.com/contact", []string{"contact:unsafe_url"}},
+		{"http://127.0.0.1/", []string{"contact:unsafe_url"}},
+	}
+
+	for _, tt := range tests {
+		errs, err := schema.ValidateCtx(context.Background(), map[string]interface{}{"contact": tt.value})
+		if err != nil {
+			t.Fatalf("ValidateCtx() = %v", err)
+		}
+		if got := errorCodes(errs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("safe_outbound_url|email on %q = %v; want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSafeOutboundURLUnchecked(t *testing.T) {
+	v := NewValidator("webhook", "http://internal.example.com/").Resolver(testResolver).SafeOutboundURL()
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"webhook:unchecked"}) {
+		t.Errorf("GetError() without ValidateCtx() = %v", got)
+	}
+	v.ValidateCtx(context.Background())
+	if got := errorCodes(v.GetError()); !reflect.DeepEqual(got, []string{"webhook:unsafe_url"}) {
+		t.Errorf("GetError() after ValidateCtx() = %v", got)
+	}
+}
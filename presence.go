@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+/*
+This function makes the zero value of the field count as missing, e.g. "", 0 or false,
+as well as empty slices and maps
+
+returns: *Validator
+
+Note: Like NotRequired(), call it before any other rule of the field.
+
+Example:
+
+	validator := NewValidator("nickname", data.Nickname).NotRequired().ZeroIsEmpty().Min(3)
+*/
+func (v *Validator) ZeroIsEmpty() *Validator {
+	v.zeroIsEmpty = true
+	return v
+}
+
+// isEmpty reports whether the current field counts as missing
+func (v *Validator) isEmpty() bool {
+	return isNullish(v.data) || v.zeroIsEmpty && isZero(v.data)
+}
+
+// isZero reports whether data is the zero value of its type or an empty slice or map
+func isZero(data interface{}) bool {
+	rv := reflect.ValueOf(data)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// deref returns the value data points to, nil for a nil pointer
+func deref(data interface{}) interface{} {
+	if data == nil || reflect.TypeOf(data).Kind() != reflect.Ptr {
+		return data
+	}
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+/*
+Presence records the keys that appeared in a JSON payload, by path, e.g. "name",
+"address.city" or "items[3].sku". Keys whose value is null are recorded as false, they count as missing
+except for the fields of a JSON Schema accepting null, see CompileJSONSchema().
+*/
+type Presence map[string]bool
+
+/*
+This function reports whether path appeared in the payload
+
+returns: bool
+*/
+func (p Presence) Has(path string) bool {
+	return p[path]
+}
+
+/*
+This function decodes a JSON payload into dst and records which keys it contains
+
+- data: JSON payload
+
+- dst: pointer passed to json.Unmarshal
+
+- returns: Presence of the payload keys, and the decoding error if any
+*/
+func DecodeJSON(data []byte, dst interface{}) (Presence, error) {
+	if err := json.Unmarshal(data, dst); err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	presence := Presence{}
+	presence.record("", doc)
+	return presence, nil
+}
+
+func (p Presence) record(path string, doc interface{}) {
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range doc {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			// a null value counts as missing, so that it fails required fields
+			if value == nil {
+				p[keyPath] = false
+				continue
+			}
+			p[keyPath] = true
+			p.record(keyPath, value)
+		}
+	case []interface{}:
+		for i, value := range doc {
+			p.record(path+"["+strconv.Itoa(i)+"]", value)
+		}
+	}
+}
+
+/*
+This function decodes a JSON payload into the struct dst and validates it with its `validate` tags,
+see ValidateStruct()
+
+- data: JSON payload
+
+- dst: pointer to the struct to decode into
+
+- returns: ValidationErrors, nil when every field is valid, and the decoding error if any
+
+Note: Required fields are judged on the keys of the payload, so a quantity of 0 that was sent
+is present while a key that was left out is missing, whatever its Go zero value.
+*/
+func ValidateJSON(data []byte, dst interface{}) (ValidationErrors, error) {
+	return ValidateJSONCtx(context.Background(), data, dst)
+}
+
+/*
+This function is ValidateJSON() running the context-aware rules, e.g. CustomCtx() and SafeOutboundURL(), with ctx
+
+- ctx: bounds the context-aware rules, e.g. the context of the HTTP request
+
+- returns: ValidationErrors, and the decoding error or ctx.Err() when ctx ended before the rules completed
+
+Example:
+
+	errs, err := validator.ValidateJSONCtx(r.Context(), body, &signup)
+*/
+func ValidateJSONCtx(ctx context.Context, data []byte, dst interface{}) (ValidationErrors, error) {
+	presence, err := DecodeJSON(data, dst)
+	if err != nil {
+		return nil, err
+	}
+	return StructSchema(reflect.TypeOf(dst)).validate(ctx, dst, presence)
+}
+
+/*
+This function decodes a JSON payload into dst and validates it against the schema,
+judging required fields on the keys of the payload, see ValidateJSON()
+
+- data: JSON payload
+
+- dst: pointer to a struct or map[string]interface{} to decode into
+
+- returns: ValidationErrors, nil when every field is valid, and the decoding error if any
+*/
+func (s *Schema) ValidateJSON(data []byte, dst interface{}) (ValidationErrors, error) {
+	return s.ValidateJSONCtx(context.Background(), data, dst)
+}
+
+/*
+This function is Schema.ValidateJSON() running the context-aware rules with ctx, see ValidateJSONCtx()
+
+- returns: ValidationErrors, and the decoding error or ctx.Err() when ctx ended before the rules completed
+*/
+func (s *Schema) ValidateJSONCtx(ctx context.Context, data []byte, dst interface{}) (ValidationErrors, error) {
+	presence, err := DecodeJSON(data, dst)
+	if err != nil {
+		return nil, err
+	}
+	return s.validate(ctx, deref(dst), presence)
+}
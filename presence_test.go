@@ -0,0 +1,158 @@
+package validator
+
+import "testing"
+
+func TestPresence(t *testing.T) {
+	quantity := 0
+	var missing *int
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"nil pointer", missing, false},
+		{"zero int", 0, true},
+		{"zero int64", int64(0), true},
+		{"zero uint", uint(0), true},
+		{"zero float32", float32(0), true},
+		{"pointer to zero", &quantity, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("quantity", tt.value).Min(0)
+			if (v.GetError() == nil) != tt.expected {
+				t.Errorf("required quantity %s = %v; want %v", tt.name, v.GetError() == nil, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPointerDereference(t *testing.T) {
+	email := "invalid-email"
+	errs := NewValidator("email", &email).Email().GetError()
+	if len(errs) != 1 || errs[0].Code != CodeEmail || errs[0].Value != "invalid-email" {
+		t.Errorf("rules should run on the dereferenced value, got %v", errs)
+	}
+}
+
+func TestZeroIsEmpty(t *testing.T) {
+	errs := NewValidator("quantity", 0).ZeroIsEmpty().Min(1).GetError()
+	if len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("ZeroIsEmpty() should report a zero required field as missing, got %v", errs)
+	}
+
+	v := NewValidator("nickname", "").NotRequired().ZeroIsEmpty().Min(3)
+	v.NextField("tags", []string{}).NotRequired().ZeroIsEmpty().Min(1)
+	v.NextField("count", 0).Min(1)
+	if errs := v.GetError(); len(errs) != 1 || errs[0].Field != "count" || errs[0].Code != CodeMin {
+		t.Errorf("ZeroIsEmpty() should only apply to its own field, got %v", errs)
+	}
+}
+
+type presenceOrder struct {
+	Quantity int               `json:"quantity" validate:"required,max=10"`
+	Note     string            `json:"note" validate:"omitempty,min=3"`
+	Address  *presenceAddress  `json:"address"`
+	Items    []presenceItem    `json:"items"`
+	Labels   map[string]string `json:"labels"`
+}
+
+type presenceAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type presenceItem struct {
+	SKU   string `json:"sku" validate:"required"`
+	Price int    `json:"price" validate:"required"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	var order presenceOrder
+	presence, err := DecodeJSON([]byte(`{"quantity": 0, "address": {"city": ""}, "items": [{"sku": "a"}]}`), &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"quantity", "address", "address.city", "items", "items[0].sku"} {
+		if !presence.Has(path) {
+			t.Errorf("Presence should contain %q", path)
+		}
+	}
+	if presence.Has("note") || presence.Has("items[0].price") {
+		t.Errorf("Presence should not contain missing keys, got %v", presence)
+	}
+
+	if _, err := DecodeJSON([]byte(`{"quantity": "x"}`), &order); err == nil {
+		t.Errorf("DecodeJSON() should return decoding errors")
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	var order presenceOrder
+	errs, err := ValidateJSON([]byte(`{"quantity": 0, "note": "", "address": {"city": ""}, "items": [{"sku": "a", "price": 0}]}`), &order)
+	if err != nil || errs != nil {
+		t.Errorf("zero values sent in the payload are present, got %v, %v", errs, err)
+	}
+
+	errs, err = ValidateJSON([]byte(`{"address": {}, "items": [{"price": 5}]}`), &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"quantity", "address.city", "items[0].sku"}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, field := range want {
+		if errs[i].Field != field || errs[i].Code != CodeRequired {
+			t.Errorf("error %d = %s %s; want %s required", i, errs[i].Field, errs[i].Code, field)
+		}
+	}
+
+	errs, err = ValidateJSON([]byte(`{"quantity": null, "items": [{"sku": null, "price": 1}]}`), &order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 2 || errs[0].Field != "quantity" || errs[0].Code != CodeRequired || errs[1].Field != "items[0].sku" {
+		t.Errorf("null values should fail required fields, got %v", errs)
+	}
+}
+
+func TestStructZeroValuesPresent(t *testing.T) {
+	type stock struct {
+		Qty      int      `json:"qty" validate:"min=0"`
+		Reserved uint     `json:"reserved" validate:"required"`
+		Active   bool     `json:"active" validate:"required"`
+		Price    float64  `json:"price" validate:"required,min=0"`
+		Note     *string  `json:"note" validate:"required"`
+		Tags     []string `json:"tags" validate:"required"`
+	}
+	errs := ValidateStruct(stock{Tags: []string{}})
+	if len(errs) != 1 || errs[0].Field != "note" || errs[0].Code != CodeRequired {
+		t.Errorf("zero values should be present and nil pointers missing, got %v", errs)
+	}
+
+	errs = ValidateStruct(stock{Note: new(string)})
+	if len(errs) != 1 || errs[0].Field != "tags" || errs[0].Code != CodeRequired {
+		t.Errorf("a nil slice should be missing, got %v", errs)
+	}
+}
+
+func TestSchemaValidateJSON(t *testing.T) {
+	schema := NewSchema(Field("quantity").Min(0), Field("note").NotRequired().Min(3))
+
+	var payload map[string]interface{}
+	errs, err := schema.ValidateJSON([]byte(`{"quantity": 0}`), &payload)
+	if err != nil || errs != nil {
+		t.Errorf("Schema.ValidateJSON() = %v, %v", errs, err)
+	}
+	errs, _ = schema.ValidateJSON([]byte(`{"quantity": null}`), &payload)
+	if len(errs) != 1 || errs[0].Code != CodeRequired {
+		t.Errorf("Schema.ValidateJSON() should fail a null required field, got %v", errs)
+	}
+	errs, _ = schema.ValidateJSON([]byte(`{"note": "ab"}`), &payload)
+	if len(errs) != 2 || errs[0].Code != CodeRequired || errs[1].Code != CodeMin {
+		t.Errorf("Schema.ValidateJSON() errors = %v", errs)
+	}
+}
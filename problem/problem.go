@@ -0,0 +1,178 @@
+/*
+Package problem renders validation failures as RFC 9457 Problem Details, i.e. application/problem+json
+
+Example:
+
+	if errs := vApp.GetError(); errs != nil {
+	    problem.Write(w, r, errs)
+	    return
+	}
+
+writes, with status 422:
+
+	{
+	  "type": "about:blank",
+	  "title": "Unprocessable Entity",
+	  "status": 422,
+	  "detail": "1 field is invalid",
+	  "instance": "/users",
+	  "errors": [{"pointer": "#/email", "code": "email", "message": "must be a valid email"}]
+	}
+*/
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validator "github.com/mcctrix/ctrix-validator"
+	"github.com/mcctrix/ctrix-validator/httpbind"
+)
+
+// ContentType is the media type of Problem Details documents
+const ContentType = "application/problem+json"
+
+// BlankType is the type of a problem described by its status alone
+const BlankType = "about:blank"
+
+/*
+Details is an RFC 9457 Problem Details object, with the "errors" extension listing the invalid fields
+
+It implements http.Handler, writing itself with its status.
+*/
+type Details struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title,omitempty"`
+	Status   int          `json:"status,omitempty"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+/*
+FieldError is an invalid field of a Details
+
+Pointer is a JSON Pointer to the field in the request body as a URI fragment, e.g. "#/items/0/sku".
+*/
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+/*
+Renderer builds Problem Details from errors, the zero value renders every problem with the "about:blank" type
+*/
+type Renderer struct {
+	// ValidationType is the type URI of validation failures, e.g. "https://example.com/problems/validation"
+	ValidationType string
+
+	// ValidationTitle is the title of validation failures, the status text when empty
+	ValidationTitle string
+
+	// Types are the type URIs of the other problems by status, e.g. 415, 413 or 400 for httpbind errors
+	Types map[int]string
+}
+
+// DefaultRenderer is the Renderer used by Render() and Write()
+var DefaultRenderer = &Renderer{}
+
+/*
+This function builds the Problem Details of an error with DefaultRenderer, see Renderer.Render()
+
+returns: *Details
+*/
+func Render(r *http.Request, err error) *Details {
+	return DefaultRenderer.Render(r, err)
+}
+
+/*
+This function writes the Problem Details of an error with DefaultRenderer, see Renderer.Write()
+
+Its signature is the one of httpbind.Binder.OnError.
+*/
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultRenderer.Write(w, r, err)
+}
+
+/*
+This function builds the Problem Details of an error
+
+- r: the request, its URI becomes the instance, nil to leave the instance out
+
+- err: validator.ValidationErrors or any error wrapping them answer 422 Unprocessable Entity with every field
+error; an *httpbind.Error answers its own status; any other error answers 500 Internal Server Error,
+without detail so that internals do not leak
+
+returns: *Details
+*/
+func (rd *Renderer) Render(r *http.Request, err error) *Details {
+	d := &Details{Status: http.StatusInternalServerError}
+	if r != nil {
+		d.Instance = r.URL.RequestURI()
+	}
+
+	var errs validator.ValidationErrors
+	var bindErr *httpbind.Error
+	switch {
+	case errors.As(err, &errs):
+		d.Status = http.StatusUnprocessableEntity
+	case errors.As(err, &bindErr):
+		var response httpbind.Response
+		d.Status, response = httpbind.Render(bindErr)
+		d.Detail = response.Message
+	}
+
+	if errs != nil {
+		d.Type = rd.ValidationType
+		d.Title = rd.ValidationTitle
+		d.Detail = invalidFields(errs)
+		d.Errors = make([]FieldError, len(errs))
+		for i, e := range errs {
+			d.Errors[i] = FieldError{Pointer: "#" + e.Pointer(), Code: e.Code, Message: e.Message}
+		}
+	} else {
+		d.Type = rd.Types[d.Status]
+	}
+	if d.Type == "" {
+		d.Type = BlankType
+	}
+	if d.Title == "" {
+		d.Title = http.StatusText(d.Status)
+	}
+	return d
+}
+
+/*
+This function writes the Problem Details of an error as application/problem+json, see Renderer.Render()
+*/
+func (rd *Renderer) Write(w http.ResponseWriter, r *http.Request, err error) {
+	rd.Render(r, err).ServeHTTP(w, r)
+}
+
+/*
+This function writes the Problem Details with its status as application/problem+json
+*/
+func (d *Details) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	status := d.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(d)
+}
+
+// invalidFields counts the fields with an error, e.g. "2 fields are invalid"
+func invalidFields(errs validator.ValidationErrors) string {
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Pointer()] = true
+	}
+	if len(fields) == 1 {
+		return "1 field is invalid"
+	}
+	return strconv.Itoa(len(fields)) + " fields are invalid"
+}
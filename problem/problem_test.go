@@ -0,0 +1,123 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	validator "github.com/mcctrix/ctrix-validator"
+	"github.com/mcctrix/ctrix-validator/httpbind"
+)
+
+func invalidOrder() validator.ValidationErrors {
+	order := map[string]interface{}{
+		"email": "ann",
+		"items": []interface{}{map[string]interface{}{"sku": "a/b"}},
+	}
+	schema := validator.NewSchema(
+		validator.Field("email").Email().Max(30),
+		validator.Field("items").Each(validator.Elem().Nested(validator.NewSchema(
+			validator.Field("sku").AlphaNumeric(),
+			validator.Field("quantity"),
+		))),
+	)
+	return schema.Validate(order)
+}
+
+func TestRenderValidationErrors(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders?draft=1", nil)
+	got := Render(r, invalidOrder())
+
+	want := &Details{
+		Type:     BlankType,
+		Title:    "Unprocessable Entity",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "3 fields are invalid",
+		Instance: "/orders?draft=1",
+		Errors: []FieldError{
+			{Pointer: "#/email", Code: validator.CodeEmail, Message: "must be a valid email"},
+			{Pointer: "#/items/0/sku", Code: validator.CodeAlphaNumeric, Message: "must contain only alphabets and numbers"},
+			{Pointer: "#/items/0/quantity", Code: validator.CodeRequired, Message: "Field is Required"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Render() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestRendererTypes(t *testing.T) {
+	rd := &Renderer{
+		ValidationType:  "https://example.com/problems/validation",
+		ValidationTitle: "Your request is not valid",
+		Types:           map[int]string{http.StatusUnsupportedMediaType: "https://example.com/problems/media-type"},
+	}
+
+	d := rd.Render(nil, fmt.Errorf("creating order: %w", invalidOrder()))
+	if d.Type != rd.ValidationType || d.Title != rd.ValidationTitle || d.Status != 422 || d.Instance != "" {
+		t.Errorf("wrapped ValidationErrors: Render() = %+v", d)
+	}
+
+	_, err := httpbind.Bind[map[string]interface{}](httptest.NewRequest("POST", "/", strings.NewReader("{}")), validator.NewSchema())
+	d = rd.Render(nil, err)
+	want := &Details{
+		Type:   "https://example.com/problems/media-type",
+		Title:  "Unsupported Media Type",
+		Status: http.StatusUnsupportedMediaType,
+		Detail: `unsupported media type ""`,
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("httpbind error: Render() = %+v; want %+v", d, want)
+	}
+
+	d = rd.Render(nil, errors.New("database is down"))
+	if d.Type != BlankType || d.Status != http.StatusInternalServerError || d.Detail != "" || d.Title != "Internal Server Error" {
+		t.Errorf("other error: Render() = %+v", d)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	Write(w, httptest.NewRequest("POST", "/users", nil), validator.NewValidator("email", "ann").Email().GetError())
+
+	if w.Code != http.StatusUnprocessableEntity || w.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("Write(): status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"type":     "about:blank",
+		"title":    "Unprocessable Entity",
+		"status":   422.0,
+		"detail":   "1 field is invalid",
+		"instance": "/users",
+		"errors": []interface{}{
+			map[string]interface{}{"pointer": "#/email", "code": "email", "message": "must be a valid email"},
+		},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Write() = %v\nwant %v", doc, want)
+	}
+}
+
+func TestBinderOnError(t *testing.T) {
+	b := &httpbind.Binder{OnError: Write}
+	handler := httpbind.Middleware[map[string]interface{}](b, validator.NewSchema(validator.Field("name")))(http.NotFoundHandler())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/pets", strings.NewReader(`{"age": 3}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(w, r)
+
+	var d Details
+	json.Unmarshal(w.Body.Bytes(), &d)
+	if w.Code != 422 || len(d.Errors) != 1 || d.Errors[0].Pointer != "#/name" || d.Errors[0].Code != validator.CodeRequired {
+		t.Errorf("OnError: status %d, %+v", w.Code, d)
+	}
+}
@@ -0,0 +1,209 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+/*
+RuleFunc validates the value of a field with the parameters of a named rule
+
+returns: nil when the value is valid. A returned ValidationError keeps its Code, Message and Params,
+any other error is reported with the rule name as Code and the error text as Message
+*/
+type RuleFunc func(value interface{}, params []string) error
+
+var (
+	ruleRegistryMu sync.RWMutex
+	ruleRegistry   = map[string]RuleFunc{}
+)
+
+/*
+This function registers a named rule for every validator, schema and struct tag
+
+- name: name of the rule, also the Code of its errors
+
+- fn: validates the value, it must be safe for concurrent use
+
+Note: Register rules at program start, before the structs using them in tags are first validated.
+Panics when name is empty or fn is nil.
+
+Example:
+
+	validator.RegisterRule("sku", func(value interface{}, params []string) error {
+	    if s, ok := value.(string); ok && !skuRegex.MatchString(s) {
+	        return errors.New("must be a valid SKU")
+	    }
+	    return nil
+	})
+
+	type item struct {
+	    SKU string `json:"sku" validate:"sku"`
+	}
+*/
+func RegisterRule(name string, fn RuleFunc) {
+	if name == "" || fn == nil {
+		panic("validator: RegisterRule needs a name and a function")
+	}
+	ruleRegistryMu.Lock()
+	defer ruleRegistryMu.Unlock()
+	ruleRegistry[name] = fn
+}
+
+func registeredRule(name string) (RuleFunc, bool) {
+	ruleRegistryMu.RLock()
+	defer ruleRegistryMu.RUnlock()
+	fn, ok := ruleRegistry[name]
+	return fn, ok
+}
+
+func isRegisteredRule(name string) bool {
+	_, ok := registeredRule(name)
+	return ok
+}
+
+// knowsRule reports whether name is registered on the schema or globally
+func (s *Schema) knowsRule(name string) bool {
+	if _, ok := s.rules[name]; ok {
+		return true
+	}
+	return isRegisteredRule(name)
+}
+
+/*
+This function registers a named rule for this schema and its nested schemas only,
+taking precedence over the rules registered with RegisterRule()
+
+returns: *Schema, a copy of the schema
+
+Note: Rule strings and struct tags using the rule are parsed with Schema.ParseRules() and Schema.Struct().
+*/
+func (s *Schema) RegisterRule(name string, fn RuleFunc) *Schema {
+	if name == "" || fn == nil {
+		panic("validator: RegisterRule needs a name and a function")
+	}
+	schema := *s
+	schema.rules = make(map[string]RuleFunc, len(s.rules)+1)
+	for ruleName, ruleFn := range s.rules {
+		schema.rules[ruleName] = ruleFn
+	}
+	schema.rules[name] = fn
+	return &schema
+}
+
+// lookupRule resolves a rule name in the enclosing schemas, innermost first, then in the global registry
+func (v *Validator) lookupRule(name string) (RuleFunc, bool) {
+	for i := len(v.rules) - 1; i >= 0; i-- {
+		if fn, ok := v.rules[i][name]; ok {
+			return fn, true
+		}
+	}
+	return registeredRule(name)
+}
+
+/*
+This function applies a rule registered with RegisterRule() or Schema.RegisterRule()
+
+- name: name of the rule
+
+- params: parameters passed to the rule
+
+returns: *Validator
+
+Note: Panics when no rule is registered under name, as it is a programming error.
+*/
+func (v *Validator) Rule(name string, params ...string) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	fn, ok := v.lookupRule(name)
+	if !ok {
+		panic(fmt.Sprintf("validator: unknown rule %q on field %s", name, v.fieldName))
+	}
+	if err := fn(v.data, params); err != nil {
+		field := ValidationError{Field: v.fieldName, Code: name, Value: v.data}
+		if len(params) > 0 {
+			field.Params = map[string]interface{}{"params": params}
+		}
+		v.appendError(ruleError(field, err))
+	}
+	return v
+}
+
+/*
+This function applies a one-off rule
+
+- fn: returns nil when the value is valid. A returned ValidationError keeps its Code and Message,
+any other error is reported with Code "custom" and the error text as Message
+
+returns: *Validator
+
+Example:
+
+	validator := NewValidator("tenant", data.Tenant).Custom(func(value interface{}) error {
+	    if !strings.HasPrefix(value.(string), "t-") {
+	        return errors.New("must start with t-")
+	    }
+	    return nil
+	})
+*/
+func (v *Validator) Custom(fn func(value interface{}) error) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+
+	if err := fn(v.data); err != nil {
+		v.appendError(customError(ValidationError{Field: v.fieldName, Value: v.data}, err))
+	}
+	return v
+}
+
+// ruleError turns the error of a named rule into a ValidationError of field, like customError,
+// but plain errors and ValidationErrors without a Code keep the rule name as Code
+func ruleError(field ValidationError, err error) ValidationError {
+	var target ValidationError
+	if !errors.As(err, &target) {
+		field.Message = err.Error()
+		return field
+	}
+	if target.Code != "" {
+		field.Code = target.Code
+	}
+	field.Message = target.Message
+	if target.Params != nil {
+		field.Params = target.Params
+	}
+	return field
+}
+
+/*
+This function applies a named rule, see Validator.Rule()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Rule(name string, params ...string) *SchemaField {
+	return f.with(schemaRule{code: name, args: stringArgs(params), apply: func(v *Validator) *Validator {
+		return v.Rule(name, params...)
+	}})
+}
+
+/*
+This function applies a one-off rule, see Validator.Custom(). fn must be safe for concurrent use
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Custom(fn func(value interface{}) error) *SchemaField {
+	return f.with(schemaRule{code: CodeCustom, apply: func(v *Validator) *Validator {
+		return v.Custom(fn)
+	}})
+}
+
+func stringArgs(params []string) []interface{} {
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	return args
+}
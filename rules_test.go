@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var skuRegex = regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+
+func init() {
+	RegisterRule("sku", func(value interface{}, params []string) error {
+		if s, ok := value.(string); ok && !skuRegex.MatchString(s) {
+			return errors.New("must be a valid SKU")
+		}
+		return nil
+	})
+	RegisterRule("tenant", func(value interface{}, params []string) error {
+		for _, tenant := range params {
+			if value == tenant {
+				return nil
+			}
+		}
+		return ValidationError{Message: "must be one of " + strings.Join(params, ", ")}
+	})
+}
+
+func TestRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		rule  string
+		code  string
+	}{
+		{"valid sku", "ABC-1234", "sku", ""},
+		{"invalid sku", "abc", "sku", "sku"},
+		{"valid tenant", "beta", "tenant", ""},
+		{"invalid tenant", "gamma", "tenant", "tenant"},
+		{"missing", nil, "sku", CodeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewValidator("field", tt.value).Rule(tt.rule, "acme", "beta").GetError()
+			if tt.code == "" {
+				if errs != nil {
+					t.Errorf("Rule(%s) = %v; want no error", tt.rule, errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Code != tt.code {
+				t.Errorf("Rule(%s) = %v; want code %s", tt.rule, errs, tt.code)
+			}
+		})
+	}
+
+	errs := NewValidator("tenant", "gamma").Rule("tenant", "acme", "beta").GetError()
+	if errs[0].Message != "must be one of acme, beta" {
+		t.Errorf("Rule() message = %q", errs[0].Message)
+	}
+}
+
+func TestRuleFirstErrorPerField(t *testing.T) {
+	calls := 0
+	RegisterRule("counted", func(value interface{}, params []string) error {
+		calls++
+		return nil
+	})
+	NewValidator("sku", "ab").Min(3).Rule("counted").Rule("sku")
+	if calls != 0 {
+		t.Errorf("rules should not run after the first error of a field, ran %d", calls)
+	}
+}
+
+func TestRuleUnknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Rule() with an unknown name should panic")
+		}
+	}()
+	NewValidator("field", "value").Rule("unknown")
+}
+
+func TestCustom(t *testing.T) {
+	check := func(value interface{}) error {
+		if !strings.HasPrefix(value.(string), "t-") {
+			return errors.New("must start with t-")
+		}
+		return nil
+	}
+
+	errs := NewValidator("tenant", "acme").Custom(check).GetError()
+	if len(errs) != 1 || errs[0].Code != CodeCustom || errs[0].Message != "must start with t-" {
+		t.Errorf("Custom() = %v", errs)
+	}
+	if errs := NewValidator("tenant", "t-acme").Custom(check).GetError(); errs != nil {
+		t.Errorf("Custom() = %v; want no error", errs)
+	}
+}
+
+func TestSchemaRegisterRule(t *testing.T) {
+	even := func(value interface{}, params []string) error {
+		if n, ok := value.(int); ok && n%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	}
+	itemSchema := NewSchema(Field("sku").Rule("sku"), Field("quantity").Rule("even"))
+	schema := NewSchema(Field("items").Each(Elem().Nested(itemSchema))).RegisterRule("even", even)
+
+	errs := schema.Validate(map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"sku": "ABC-1234", "quantity": 3}},
+	})
+	if len(errs) != 1 || errs[0].Field != "items[0].quantity" || errs[0].Code != "even" {
+		t.Errorf("Schema.RegisterRule() = %v", errs)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("schema rules should not leak into other schemas")
+			}
+		}()
+		itemSchema.Validate(map[string]interface{}{"sku": "ABC-1234", "quantity": 3})
+	}()
+}
+
+func TestRuleTag(t *testing.T) {
+	type item struct {
+		SKU    string `json:"sku" validate:"sku"`
+		Tenant string `json:"tenant" validate:"tenant=acme beta"`
+	}
+	errs := ValidateStruct(item{SKU: "abc", Tenant: "gamma"})
+	if len(errs) != 2 || errs[0].Code != "sku" || errs[1].Code != "tenant" || len(errs[1].Params["params"].([]string)) != 2 {
+		t.Errorf("registered rule tags = %v", errs)
+	}
+}
+
+func TestSchemaRegisterRuleParsed(t *testing.T) {
+	odd := func(value interface{}, params []string) error {
+		if n, ok := value.(int); ok && n%2 == 0 {
+			return errors.New("must be odd")
+		}
+		return nil
+	}
+	schema := NewSchema().RegisterRule("odd", odd)
+
+	parsed, err := schema.ParseRules("quantity", "required,odd")
+	if err != nil {
+		t.Fatalf("Schema.ParseRules() = %v", err)
+	}
+	if errs := parsed.Validate(map[string]interface{}{"quantity": 4}); len(errs) != 1 || errs[0].Code != "odd" {
+		t.Errorf("Schema.ParseRules() rules = %v", errs)
+	}
+	if _, err := ParseRules("quantity", "odd"); err == nil {
+		t.Errorf("ParseRules() should not know the rules of a schema")
+	}
+
+	type line struct {
+		Quantity int `json:"quantity" validate:"odd"`
+	}
+	type order struct {
+		Quantity int    `json:"quantity" validate:"odd"`
+		Lines    []line `json:"lines"`
+	}
+	errs := schema.Struct(reflect.TypeOf(order{})).Validate(order{Quantity: 2, Lines: []line{{Quantity: 3}, {Quantity: 6}}})
+	if len(errs) != 2 || errs[0].Field != "quantity" || errs[1].Field != "lines[1].quantity" {
+		t.Errorf("Schema.Struct() = %v", errs)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("StructSchema() should panic on a rule only registered on a schema")
+		}
+	}()
+	StructSchema(reflect.TypeOf(order{}))
+}
@@ -0,0 +1,737 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Schema is a reusable set of field rules, declared once and validated many times
+
+A Schema and its fields are immutable once built, so it is safe to share a single Schema across goroutines.
+
+Example:
+
+	var userSchema = validator.NewSchema(
+	    validator.Field("email").Email().Max(30),
+	    validator.Field("username").Min(5).Max(25),
+	    validator.Field("questNum").NotRequired().ZeroIsEmpty().Min(10).Max(20),
+	)
+
+	errs := userSchema.Validate(payload)
+*/
+type Schema struct {
+	fields  []*SchemaField
+	workers int
+	strict  bool
+	// rules registered with Schema.RegisterRule()
+	rules map[string]RuleFunc
+
+	allErrors bool
+	maxErrors int
+	// clock of the date range rules, see Schema.Clock()
+	now func() time.Time
+	// resolver of SafeOutboundURL(), see Schema.Resolver()
+	resolver Resolver
+}
+
+/*
+SchemaField holds the rule chain of a single field in a Schema
+
+Every method returns a new SchemaField, the receiver is never modified.
+*/
+type SchemaField struct {
+	name        string
+	optional    bool
+	zeroIsEmpty bool
+	allErrors   bool
+	rules       []schemaRule
+	// nullable fields accept null, known to schemas built by CompileJSONSchema()
+	nullable bool
+	// typ is the Go type of struct fields, known to schemas built by StructSchema()
+	typ reflect.Type
+}
+
+// schemaRule is a single rule of a SchemaField, replayed on a Validator at validation time
+type schemaRule struct {
+	code  string
+	args  []interface{}
+	apply func(*Validator) *Validator
+	// presence rules decide whether the field is required, they also run when the field is missing
+	presence bool
+}
+
+/*
+- fields: the fields of the schema, built with Field()
+
+- returns: *Schema
+*/
+func NewSchema(fields ...*SchemaField) *Schema {
+	return &Schema{fields: append([]*SchemaField(nil), fields...)}
+}
+
+/*
+- fieldName: name of the field, matched against map keys or struct json/field names
+
+- returns: *SchemaField
+
+- Note: By Default field is considered required, please use NotRequired() to make it optional
+*/
+func Field(fieldName string) *SchemaField {
+	return &SchemaField{name: fieldName}
+}
+
+/*
+This function validates data against the schema
+
+- data: map[string]interface{}, struct or pointer to struct
+
+- returns: ValidationErrors, nil when every field is valid
+
+Note: Struct fields are matched by their `json` tag name first and by their Go field name otherwise.
+A required field that is missing from a map or struct reports a required error. Nil pointer, slice and map
+struct fields count as missing, zero values such as 0, false or "" are present unless ZeroIsEmpty() is used,
+use ValidateJSON() to judge fields on the keys of the payload instead.
+CustomCtx() rules run with context.Background(), use ValidateCtx() to pass a context.
+*/
+func (s *Schema) Validate(data interface{}) ValidationErrors {
+	errs, _ := s.ValidateCtx(context.Background(), data)
+	return errs
+}
+
+/*
+This function validates data against the schema, running CustomCtx() rules with ctx
+
+- ctx: cancelling ctx or exceeding its deadline stops the remaining CustomCtx() rules
+
+- data: map[string]interface{}, struct or pointer to struct
+
+- returns: ValidationErrors, nil when every field is valid, and ctx.Err() when the rules were interrupted
+*/
+func (s *Schema) ValidateCtx(ctx context.Context, data interface{}) (ValidationErrors, error) {
+	return s.validate(ctx, data, nil)
+}
+
+func (s *Schema) validate(ctx context.Context, data interface{}, presence Presence) (ValidationErrors, error) {
+	lookup, ok := newFieldLookup(data)
+	if !ok {
+		panic(fmt.Sprintf("validator: Validate expects a map[string]interface{}, struct or non-nil pointer to struct, got %T", data))
+	}
+
+	v := &Validator{workers: s.workers, strict: s.strict, presence: presence, allErrors: s.allErrors, maxErrors: s.maxErrors, now: s.now, resolver: s.resolver}
+	s.validateFields(v, lookup)
+	if len(v.async) == 0 {
+		return v.GetError(), nil
+	}
+	err := v.ValidateCtx(ctx)
+	return v.GetError(), err
+}
+
+/*
+This function enables strict mode, see Validator.Strict()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) Strict() *Schema {
+	schema := *s
+	schema.strict = true
+	return &schema
+}
+
+/*
+This function sets how many fields ValidateCtx() checks concurrently, see Validator.Workers()
+
+returns: *Schema, a copy of the schema
+*/
+func (s *Schema) Workers(n int) *Schema {
+	schema := *s
+	schema.workers = n
+	return &schema
+}
+
+func (s *Schema) validateFields(v *Validator, lookup fieldLookup) {
+	// cross-field rules may refer to fields declared later or not declared in the schema at all
+	parentLookup, parentRules := v.lookup, v.rules
+	v.lookup = lookup
+	if s.rules != nil {
+		v.rules = append(v.rules[:len(v.rules):len(v.rules)], s.rules)
+	}
+	defer func() { v.lookup, v.rules = parentLookup, parentRules }()
+
+	for _, field := range s.fields {
+		if v.limitReached() {
+			return
+		}
+		value, ok := lookup(field.name)
+		v.NextField(field.name, value)
+		if v.presence != nil {
+			sent, known := v.presence[v.fieldName]
+			// a JSON null is missing, unless the field accepts null
+			if !sent && (ok || known && field.nullable) {
+				ok = known && field.nullable
+				v.data = nil
+			}
+		}
+		field.run(v, ok)
+	}
+}
+
+// run applies the field rules to the current field of v
+func (f *SchemaField) run(v *Validator, present bool) {
+	if f.optional {
+		v.NotRequired()
+	}
+	if f.zeroIsEmpty {
+		v.ZeroIsEmpty()
+	}
+	if f.allErrors && !v.allErrors {
+		v.allErrors = true
+		defer func() { v.allErrors = false }()
+	}
+	if !present {
+		for _, r := range f.rules {
+			if r.presence {
+				r.apply(v)
+			}
+		}
+		if v.requiredField && !v.foundErr {
+			v.appendError(ValidationError{
+				Field:   v.fieldName,
+				Code:    CodeRequired,
+				Message: "Field is Required",
+			})
+		}
+		return
+	}
+	// the other rules describe the non-null values of a nullable field
+	if f.nullable && isNullish(v.data) {
+		return
+	}
+	for _, r := range f.rules {
+		r.apply(v)
+	}
+}
+
+func (f *SchemaField) with(r schemaRule) *SchemaField {
+	field := *f
+	field.rules = append(f.rules[:len(f.rules):len(f.rules)], r)
+	return &field
+}
+
+/*
+This function makes the field optional
+
+returns: *SchemaField
+*/
+func (f *SchemaField) NotRequired() *SchemaField {
+	field := *f
+	field.optional = true
+	return &field
+}
+
+/*
+This function makes the zero value of the field count as missing, see Validator.ZeroIsEmpty()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) ZeroIsEmpty() *SchemaField {
+	field := *f
+	field.zeroIsEmpty = true
+	return &field
+}
+
+/*
+This function checks if the field contains any special character
+
+returns: *SchemaField
+*/
+func (f *SchemaField) HasSpecialChar() *SchemaField {
+	return f.with(schemaRule{code: CodeHasSpecialChar, apply: (*Validator).HasSpecialChar})
+}
+
+/*
+This function checks if the field is a valid email
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Email() *SchemaField {
+	return f.with(schemaRule{code: CodeEmail, apply: (*Validator).Email})
+}
+
+/*
+This function checks if the field has minimum length
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Min(length int) *SchemaField {
+	return f.with(schemaRule{code: CodeMin, args: []interface{}{length}, apply: func(v *Validator) *Validator {
+		return v.Min(length)
+	}})
+}
+
+/*
+This function checks if the field has maximum length
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Max(length int) *SchemaField {
+	return f.with(schemaRule{code: CodeMax, args: []interface{}{length}, apply: func(v *Validator) *Validator {
+		return v.Max(length)
+	}})
+}
+
+/*
+This function checks if the field is a valid url, see Validator.Url()
+
+- policies: restrictions of the scheme, host, credentials, query, fragment and length
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Url(policies ...URLPolicy) *SchemaField {
+	args := make([]interface{}, len(policies))
+	for i, policy := range policies {
+		args[i] = policy
+	}
+	return f.with(schemaRule{code: CodeUrl, args: args, apply: func(v *Validator) *Validator {
+		return v.Url(policies...)
+	}})
+}
+
+/*
+This function checks if the field contains only alphabets
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Alpha() *SchemaField {
+	return f.with(schemaRule{code: CodeAlpha, apply: (*Validator).Alpha})
+}
+
+/*
+This function checks if the field contains only Numeric Values
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Numeric() *SchemaField {
+	return f.with(schemaRule{code: CodeNumeric, apply: (*Validator).Numeric})
+}
+
+/*
+This function checks if the field contains only AlphaNumeric Values
+
+returns: *SchemaField
+*/
+func (f *SchemaField) AlphaNumeric() *SchemaField {
+	return f.with(schemaRule{code: CodeAlphaNumeric, apply: (*Validator).AlphaNumeric})
+}
+
+/*
+This function checks if the field contains a valid date, see Validator.Date()
+
+- layouts: accepted layouts, time.DateOnly when none is given
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Date(layouts ...string) *SchemaField {
+	return f.with(schemaRule{code: CodeDate, args: stringArgs(layouts), apply: func(v *Validator) *Validator {
+		return v.Date(layouts...)
+	}})
+}
+
+/*
+This function checks if the field matches the pattern
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Match(pattern *regexp.Regexp) *SchemaField {
+	return f.with(schemaRule{code: CodeMatch, args: []interface{}{pattern}, apply: func(v *Validator) *Validator {
+		return v.Match(pattern)
+	}})
+}
+
+/*
+This function checks if the field is a valid phone number
+
+returns: *SchemaField
+*/
+func (f *SchemaField) PhoneNumber() *SchemaField {
+	return f.with(schemaRule{code: CodePhoneNumber, apply: (*Validator).PhoneNumber})
+}
+
+/*
+This function checks if the field is a valid credit card number, see Validator.CreditCard()
+
+- brands: the accepted brands, any known brand when none is given
+
+returns: *SchemaField
+*/
+func (f *SchemaField) CreditCard(brands ...CardBrand) *SchemaField {
+	args := make([]interface{}, len(brands))
+	for i, brand := range brands {
+		args[i] = string(brand)
+	}
+	return f.with(schemaRule{code: CodeCreditCard, args: args, apply: func(v *Validator) *Validator {
+		return v.CreditCard(brands...)
+	}})
+}
+
+/*
+This function checks if the field is a valid IPv4 or IPv6 address, see Validator.IPAddress()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) IPAddress() *SchemaField {
+	return f.with(schemaRule{code: CodeIPAddress, apply: (*Validator).IPAddress})
+}
+
+/*
+This function checks if the field is equal to another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) EqField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeEqField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.EqField(fieldName)
+	}})
+}
+
+/*
+This function checks if the field is not equal to another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) NeField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeNeField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.NeField(fieldName)
+	}})
+}
+
+/*
+This function checks if the field is greater than another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) GtField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeGtField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.GtField(fieldName)
+	}})
+}
+
+/*
+This function checks if the field is greater than or equal to another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) GteField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeGteField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.GteField(fieldName)
+	}})
+}
+
+/*
+This function checks if the field is less than another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) LtField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeLtField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.LtField(fieldName)
+	}})
+}
+
+/*
+This function checks if the field is less than or equal to another field of the schema
+
+returns: *SchemaField
+*/
+func (f *SchemaField) LteField(fieldName string) *SchemaField {
+	return f.with(schemaRule{code: CodeLteField, args: []interface{}{fieldName}, apply: func(v *Validator) *Validator {
+		return v.LteField(fieldName)
+	}})
+}
+
+/*
+This function applies the rules of then if the condition is true
+
+- condition: evaluated at validation time, use v.FieldValue() to read other fields
+
+- then: rules to apply, built with Elem()
+
+returns: *SchemaField
+
+Example:
+
+	validator.Field("password").When(
+	    func(v *Validator) bool {
+	        userType, _ := v.FieldValue("user_type")
+	        return userType == "admin"
+	    },
+	    validator.Elem().Min(12).HasSpecialChar(),
+	)
+*/
+func (f *SchemaField) When(condition func(v *Validator) bool, then *SchemaField) *SchemaField {
+	return f.with(schemaRule{code: "when", args: []interface{}{then}, apply: func(v *Validator) *Validator {
+		return v.When(func() bool { return condition(v) }, then.applyRules)
+	}})
+}
+
+/*
+This function applies the rules of then if the condition is false, the opposite of When()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Unless(condition func(v *Validator) bool, then *SchemaField) *SchemaField {
+	return f.When(func(v *Validator) bool { return !condition(v) }, then)
+}
+
+// applyRules applies the rules of an anonymous rule chain to the current field
+func (f *SchemaField) applyRules(v *Validator) *Validator {
+	if f.optional {
+		v.NotRequired()
+	}
+	if f.zeroIsEmpty {
+		v.ZeroIsEmpty()
+	}
+	if f.nullable && isNullish(v.data) {
+		return v
+	}
+	for _, r := range f.rules {
+		r.apply(v)
+	}
+	return v
+}
+
+/*
+This function makes the field required only if another field equals value, optional otherwise
+
+returns: *SchemaField
+*/
+func (f *SchemaField) RequiredIf(fieldName string, value interface{}) *SchemaField {
+	return f.with(schemaRule{code: CodeRequiredIf, args: []interface{}{fieldName, value}, presence: true, apply: func(v *Validator) *Validator {
+		return v.RequiredIf(fieldName, value)
+	}})
+}
+
+/*
+This function makes the field required unless another field equals value, optional otherwise
+
+returns: *SchemaField
+*/
+func (f *SchemaField) RequiredUnless(fieldName string, value interface{}) *SchemaField {
+	return f.with(schemaRule{code: CodeRequiredUnless, args: []interface{}{fieldName, value}, presence: true, apply: func(v *Validator) *Validator {
+		return v.RequiredUnless(fieldName, value)
+	}})
+}
+
+/*
+This function makes the field required if any of the other fields is present, optional otherwise
+
+returns: *SchemaField
+*/
+func (f *SchemaField) RequiredWith(fieldNames ...string) *SchemaField {
+	return f.with(schemaRule{code: CodeRequiredWith, args: []interface{}{fieldNames}, presence: true, apply: func(v *Validator) *Validator {
+		return v.RequiredWith(fieldNames...)
+	}})
+}
+
+/*
+This function makes the field required if any of the other fields is missing, optional otherwise
+
+returns: *SchemaField
+*/
+func (f *SchemaField) RequiredWithout(fieldNames ...string) *SchemaField {
+	return f.with(schemaRule{code: CodeRequiredWithout, args: []interface{}{fieldNames}, presence: true, apply: func(v *Validator) *Validator {
+		return v.RequiredWithout(fieldNames...)
+	}})
+}
+
+/*
+This function requires the field to be empty if another field equals value
+
+returns: *SchemaField
+*/
+func (f *SchemaField) ExcludedIf(fieldName string, value interface{}) *SchemaField {
+	return f.with(schemaRule{code: CodeExcludedIf, args: []interface{}{fieldName, value}, presence: true, apply: func(v *Validator) *Validator {
+		return v.ExcludedIf(fieldName, value)
+	}})
+}
+
+/*
+This function adds a context-aware rule, run by Validate() and ValidateCtx(), see Validator.CustomCtx()
+
+returns: *SchemaField
+*/
+func (f *SchemaField) CustomCtx(fn func(ctx context.Context, value interface{}) error) *SchemaField {
+	return f.with(schemaRule{code: CodeCustom, apply: func(v *Validator) *Validator {
+		return v.CustomCtx(fn)
+	}})
+}
+
+/*
+Allow transformation during validation, the function must be safe for concurrent use
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Transform(fn func(interface{}) interface{}) *SchemaField {
+	return f.with(schemaRule{code: "transform", apply: func(v *Validator) *Validator {
+		return v.Transform(fn)
+	}})
+}
+
+/*
+This function validates the current field as a nested object against schema
+
+- schema: schema of the nested object, its errors are reported as "field.name"
+
+returns: *SchemaField
+*/
+func (f *SchemaField) Nested(schema *Schema) *SchemaField {
+	return f.with(schemaRule{code: "nested", args: []interface{}{schema}, apply: func(v *Validator) *Validator {
+		return v.nestedSchema(schema)
+	}})
+}
+
+/*
+This function validates every element of a slice or array with the rules of elem
+
+- elem: element rules, built with Elem(), errors are reported as "field[i]"
+
+returns: *SchemaField
+
+Example:
+
+	validator.Field("items").Each(validator.Elem().Nested(itemSchema))
+*/
+func (f *SchemaField) Each(elem *SchemaField) *SchemaField {
+	return f.with(schemaRule{code: "each", args: []interface{}{elem}, apply: func(v *Validator) *Validator {
+		return v.Each(func(v *Validator) {
+			elem.run(v, true)
+		})
+	}})
+}
+
+/*
+This function starts an anonymous rule chain, for the elements of SchemaField.Each()
+and the branches of SchemaField.When() and SchemaField.Unless()
+
+returns: *SchemaField
+*/
+func Elem() *SchemaField {
+	return &SchemaField{}
+}
+
+func (v *Validator) nestedSchema(schema *Schema) *Validator {
+	if v.commonReturnCase() {
+		return v
+	}
+	lookup, ok := newFieldLookup(v.data)
+	if !ok {
+		v.typeMismatch("map or struct")
+		return v
+	}
+
+	saved := v.saveField()
+	v.enterScope()
+	schema.validateFields(v, lookup)
+	v.restoreField(saved)
+	return v
+}
+
+// fieldLookup resolves a field name to its value, reporting whether the field is present
+type fieldLookup func(name string) (interface{}, bool)
+
+// newFieldLookup returns a fieldLookup for a map or struct, ok is false for any other value.
+// Nil pointer, interface, slice and map struct fields are reported as missing, zero values of other kinds are present.
+func newFieldLookup(data interface{}) (lookup fieldLookup, ok bool) {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		return func(name string) (interface{}, bool) {
+			value, ok := data[name]
+			return value, ok
+		}, true
+	}
+
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	index := structFieldIndex(rv.Type())
+	return func(name string) (interface{}, bool) {
+		path, ok := index[name]
+		if !ok {
+			return nil, false
+		}
+		field := rv.FieldByIndex(path)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			if field.IsNil() {
+				return nil, false
+			}
+		}
+		return field.Interface(), true
+	}, true
+}
+
+var structIndexCache sync.Map // reflect.Type -> map[string][]int
+
+// structFieldIndex maps json and Go field names of a struct type to their field index.
+// Fields of untagged embedded structs are promoted, walked in declaration order: a Go name keeps the first
+// field met and a json name the last one. Unlike encoding/json, depth and tags do not settle conflicts.
+func structFieldIndex(rt reflect.Type) map[string][]int {
+	if cached, ok := structIndexCache.Load(rt); ok {
+		return cached.(map[string][]int)
+	}
+
+	index := make(map[string][]int)
+	walkStructFields(rt, nil, func(field reflect.StructField, path []int) {
+		if _, ok := index[field.Name]; !ok {
+			index[field.Name] = path
+		}
+		if name := structFieldName(field); name != field.Name {
+			index[name] = path
+		}
+	})
+
+	cached, _ := structIndexCache.LoadOrStore(rt, index)
+	return cached.(map[string][]int)
+}
+
+// walkStructFields calls fn for every exported field of rt, descending into untagged embedded structs
+func walkStructFields(rt reflect.Type, parent []int, fn func(field reflect.StructField, path []int)) {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		path := append(parent[:len(parent):len(parent)], i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			walkStructFields(field.Type, path, fn)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fn(field, path)
+	}
+}
+
+// structFieldName returns the name used for a struct field in errors,
+// preferring the json tag so that errors match the API payload.
+func structFieldName(field reflect.StructField) string {
+	if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
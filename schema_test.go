@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+var testUserSchema = NewSchema(
+	Field("email").Email().Max(30),
+	Field("username").Min(5).Max(25),
+	Field("questNum").NotRequired().ZeroIsEmpty().Min(10).Max(20),
+)
+
+func TestSchemaValidateMap(t *testing.T) {
+	errs := testUserSchema.Validate(map[string]interface{}{
+		"email":    "test@example.com",
+		"username": "ctrix_user",
+	})
+	if errs != nil {
+		t.Errorf("Validate() should not return an error for valid input, got %v", errs)
+	}
+
+	errs = testUserSchema.Validate(map[string]interface{}{
+		"email":    "invalid-email",
+		"questNum": 25,
+	})
+	if len(errs) != 3 {
+		t.Fatalf("Validate() should return 3 errors, got %v", errs)
+	}
+	want := []struct{ field, code string }{
+		{"email", CodeEmail},
+		{"username", CodeRequired},
+		{"questNum", CodeMax},
+	}
+	for i, w := range want {
+		if errs[i].Field != w.field || errs[i].Code != w.code {
+			t.Errorf("error %d = %s/%s; want %s/%s", i, errs[i].Field, errs[i].Code, w.field, w.code)
+		}
+	}
+}
+
+func TestSchemaValidateStruct(t *testing.T) {
+	type user struct {
+		Email    string `json:"email"`
+		Username string `json:"username"`
+		QuestNum int    `json:"questNum"`
+	}
+
+	if errs := testUserSchema.Validate(&user{Email: "test@example.com", Username: "ctrix_user"}); errs != nil {
+		t.Errorf("Validate() should not return an error for valid input, got %v", errs)
+	}
+
+	errs := testUserSchema.Validate(user{Email: "test@example.com", Username: "ctrix_user", QuestNum: 5})
+	if len(errs) != 1 || errs[0].Field != "questNum" || errs[0].Code != CodeMin {
+		t.Errorf("Validate() should report questNum, got %v", errs)
+	}
+}
+
+func TestSchemaFirstErrorPerField(t *testing.T) {
+	schema := NewSchema(Field("password").Min(8).HasSpecialChar().Match(regexp.MustCompile(`[0-9]`)))
+
+	errs := schema.Validate(map[string]interface{}{"password": "short"})
+	if len(errs) != 1 || errs[0].Code != CodeMin {
+		t.Errorf("Validate() should stop at the first error of a field, got %v", errs)
+	}
+}
+
+func TestSchemaFieldImmutable(t *testing.T) {
+	base := Field("name").Min(3)
+	strict := base.Alpha()
+	optional := base.NotRequired()
+
+	if len(base.rules) != 1 || len(strict.rules) != 2 {
+		t.Errorf("SchemaField methods should not modify the receiver")
+	}
+	if base.optional || !optional.optional {
+		t.Errorf("NotRequired() should not modify the receiver")
+	}
+
+	// appending to two branches of the same field must not share storage
+	a := strict.Max(5)
+	b := strict.Numeric()
+	if a.rules[2].code != CodeMax || b.rules[2].code != CodeNumeric {
+		t.Errorf("branches of a SchemaField should not share rules")
+	}
+}
+
+func TestSchemaConcurrentValidate(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := map[string]interface{}{"email": "test@example.com", "username": "ctrix_user"}
+			if i%2 == 1 {
+				data["username"] = "ctrix"[:i%5]
+			}
+			errs := testUserSchema.Validate(data)
+			if (errs == nil) != (i%2 == 0) {
+				t.Errorf("Validate() returned unexpected result %v for goroutine %d", errs, i)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,38 @@
+package validator
+
+import "fmt"
+
+// CodeTypeMismatch is reported in strict mode by rules applied to a value of a type they cannot handle
+const CodeTypeMismatch = "type_mismatch"
+
+/*
+This function enables strict mode for the rest of the validator
+
+By default a rule silently skips values of a type it cannot handle, e.g. Email() on an int.
+In strict mode such a rule reports a "type_mismatch" error naming the expected and actual types instead.
+
+returns: *Validator
+*/
+func (v *Validator) Strict() *Validator {
+	v.strict = true
+	return v
+}
+
+// typeMismatch reports, in strict mode, that the current field is not of the expected type
+func (v *Validator) typeMismatch(expected string) {
+	if !v.strict {
+		return
+	}
+
+	actual := "nil"
+	if v.data != nil {
+		actual = fmt.Sprintf("%T", v.data)
+	}
+	v.appendError(ValidationError{
+		Field:   v.fieldName,
+		Code:    CodeTypeMismatch,
+		Message: "must be of type " + expected + ", got " + actual,
+		Params:  map[string]interface{}{"expected": expected, "actual": actual},
+		Value:   v.data,
+	})
+}
@@ -0,0 +1,66 @@
+package validator
+
+import "testing"
+
+func TestStrictTypeMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		validate func(*Validator)
+		expected string
+	}{
+		{"email on int", 42, func(v *Validator) { v.Email() }, "string"},
+		{"min on bool", true, func(v *Validator) { v.Min(1) }, "string or number"},
+		{"max on slice", []int{1}, func(v *Validator) { v.Max(1) }, "string or number"},
+		{"each on string", "abc", func(v *Validator) { v.Each(func(*Validator) {}) }, "slice or array"},
+		{"keys on slice", []int{1}, func(v *Validator) { v.Keys(func(*Validator) {}) }, "map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("field", tt.value)
+			tt.validate(v)
+			if v.GetError() != nil {
+				t.Fatalf("default mode should skip mismatched types, got %v", v.GetError())
+			}
+
+			v = NewValidator("field", tt.value).Strict()
+			tt.validate(v)
+			errs := v.GetError()
+			if len(errs) != 1 || errs[0].Code != CodeTypeMismatch {
+				t.Fatalf("strict mode should report type_mismatch, got %v", errs)
+			}
+			if errs[0].Params["expected"] != tt.expected {
+				t.Errorf("expected = %v; want %v", errs[0].Params["expected"], tt.expected)
+			}
+		})
+	}
+}
+
+func TestStrictMessage(t *testing.T) {
+	errs := NewValidator("email", 42).Strict().Email().GetError()
+	if errs[0].Message != "must be of type string, got int" || errs[0].Params["actual"] != "int" {
+		t.Errorf("type_mismatch error = %+v", errs[0])
+	}
+}
+
+func TestStrictCrossField(t *testing.T) {
+	v := NewValidator("start", 10).Strict()
+	v.NextField("end", "tomorrow").GtField("start")
+	if errs := v.GetError(); len(errs) != 1 || errs[0].Code != CodeTypeMismatch || errs[0].Params["expected"] != "int" {
+		t.Errorf("strict cross-field comparison = %v", errs)
+	}
+}
+
+func TestSchemaStrict(t *testing.T) {
+	schema := NewSchema(Field("age").Min(18), Field("address").Nested(NewSchema(Field("city").Alpha())))
+	data := map[string]interface{}{"age": "18", "address": "Main St"}
+
+	if errs := schema.Validate(data); len(errs) != 1 || errs[0].Code != CodeMin {
+		t.Fatalf("default schema errors = %v", errs)
+	}
+	errs := schema.Strict().Validate(map[string]interface{}{"age": true, "address": "Main St"})
+	if len(errs) != 2 || errs[0].Code != CodeTypeMismatch || errs[1].Params["expected"] != "map or struct" {
+		t.Errorf("strict schema errors = %v", errs)
+	}
+}
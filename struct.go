@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+This function validates a struct using the rules declared in its `validate` tags
+
+- data: struct or pointer to struct to be validated
+
+- returns: ValidationErrors, nil when every field is valid
+
+The tags use the rule strings of ParseRules(), which lists the supported rules:
+
+	type user struct {
+	    Email    string `json:"email" validate:"email,max=30"`
+	    Username string `json:"username" validate:"min=5,max=25"`
+	    Website  string `json:"website" validate:"omitempty,url"`
+	}
+
+Note: Field names in errors are taken from the `json` tag when present, falling back to the Go field name.
+Fields without a `validate` tag are skipped, except structs and slices of structs which are validated with
+their own tags and reported as "address.city" or "items[3].sku", use `validate:"-"` to skip them.
+An unknown rule, a malformed parameter or a reference to a field the struct does not have panics.
+safe_outbound_url resolves host names with context.Background(), use StructSchema(t).ValidateCtx() for a deadline.
+*/
+func ValidateStruct(data interface{}) ValidationErrors {
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			panic("validator: ValidateStruct called with a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic("validator: ValidateStruct expects a struct or pointer to struct, got " + rv.Kind().String())
+	}
+
+	return StructSchema(rv.Type()).Validate(rv.Interface())
+}
+
+var structSchemaCache sync.Map // reflect.Type -> *structTemplate
+
+// structTemplate is the schema described by the tags of a struct type, with the tags using registered rules.
+// Those are checked against the registry of the schema validating the struct, as it may register its own.
+type structTemplate struct {
+	schema *Schema
+	tags   []registeredTag
+}
+
+// registeredTag is a validate tag using registered rules, names lists them
+type registeredTag struct {
+	goName string
+	tag    string
+	names  []string
+}
+
+/*
+This function builds the Schema described by the `validate` tags of a struct type
+
+- rt: struct type, pointer types are dereferenced
+
+- returns: *Schema, cached per type so repeated calls are cheap
+
+Note: Panics on an unknown rule or malformed parameter, like ValidateStruct. Use Schema.Struct()
+for tags using the rules registered with Schema.RegisterRule().
+*/
+func StructSchema(rt reflect.Type) *Schema {
+	t := structTemplateOf(rt)
+	t.check(isRegisteredRule)
+	return t.schema
+}
+
+/*
+This function adds the fields described by the `validate` tags of a struct type to the schema,
+the tags may use the rules registered with Schema.RegisterRule(), see StructSchema()
+
+returns: *Schema, a copy of the schema with the fields of the struct appended
+
+Example:
+
+	schema := validator.NewSchema().RegisterRule("tenant", tenantRule).Struct(reflect.TypeOf(Order{}))
+	errs := schema.Validate(order)
+*/
+func (s *Schema) Struct(rt reflect.Type) *Schema {
+	t := structTemplateOf(rt)
+	t.check(s.knowsRule)
+	schema := *s
+	schema.fields = append(s.fields[:len(s.fields):len(s.fields)], t.schema.fields...)
+	return &schema
+}
+
+// structTemplateOf builds the template of a struct type, cached per type
+func structTemplateOf(rt reflect.Type) *structTemplate {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if cached, ok := structSchemaCache.Load(rt); ok {
+		return cached.(*structTemplate)
+	}
+
+	t := &structTemplate{}
+	var fields []*SchemaField
+	index := structFieldIndex(rt)
+	walkStructFields(rt, nil, func(field reflect.StructField, _ []int) {
+		tag, ok := field.Tag.Lookup("validate")
+		if tag == "-" {
+			return
+		}
+
+		var f *SchemaField
+		if ok && tag != "" {
+			var names []string
+			f = tagRules(Field(structFieldName(field)), field.Name, tag, func(name string) bool {
+				names = append(names, name)
+				return true
+			})
+			checkFieldRefs(f, field.Name, index)
+			if names != nil {
+				t.tags = append(t.tags, registeredTag{goName: field.Name, tag: tag, names: names})
+			}
+		}
+		if nested := nestedStructRule(field.Type); nested != nil {
+			if f == nil {
+				f = Field(structFieldName(field)).NotRequired()
+			}
+			f = nested(f)
+		}
+		if f != nil {
+			f.typ = field.Type
+			fields = append(fields, f)
+		}
+	})
+	t.schema = NewSchema(fields...)
+
+	cached, _ := structSchemaCache.LoadOrStore(rt, t)
+	return cached.(*structTemplate)
+}
+
+// check panics when a tag uses a rule that known does not know, like an unknown built-in rule
+func (t *structTemplate) check(known func(name string) bool) {
+	for _, tag := range t.tags {
+		for _, name := range tag.names {
+			if !known(name) {
+				// parsed again to report the position of the rule
+				tagRules(Field(""), tag.goName, tag.tag, known)
+			}
+		}
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// nestedStructRule returns the rule validating a struct, or a slice or array of structs, with its own tags.
+// The nested schema is resolved at validation time so that recursive types do not recurse while building.
+func nestedStructRule(rt reflect.Type) func(*SchemaField) *SchemaField {
+	structType := func(rt reflect.Type) reflect.Type {
+		for rt.Kind() == reflect.Ptr {
+			rt = rt.Elem()
+		}
+		if rt.Kind() != reflect.Struct || rt == timeType {
+			return nil
+		}
+		return rt
+	}
+
+	if st := structType(rt); st != nil {
+		return func(f *SchemaField) *SchemaField {
+			return f.nestedStruct(st)
+		}
+	}
+	if rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		if st := structType(rt.Elem()); st != nil {
+			return func(f *SchemaField) *SchemaField {
+				return f.Each(Elem().nestedStruct(st))
+			}
+		}
+	}
+	return nil
+}
+
+func (f *SchemaField) nestedStruct(rt reflect.Type) *SchemaField {
+	return f.with(schemaRule{code: "nested", args: []interface{}{rt}, apply: func(v *Validator) *Validator {
+		t := structTemplateOf(rt)
+		t.check(func(name string) bool {
+			_, ok := v.lookupRule(name)
+			return ok
+		})
+		return v.nestedSchema(t.schema)
+	}})
+}
+
+// checkFieldRefs panics when a rule of f refers to a field the struct does not have, e.g. eq_field=passwrod.
+// Full paths such as "address.city" reach outside the struct and are not checked.
+func checkFieldRefs(f *SchemaField, goName string, index map[string][]int) {
+	for _, ref := range f.fieldRefs() {
+		if strings.ContainsAny(ref, ".[") {
+			continue
+		}
+		if _, ok := index[ref]; !ok {
+			panic("validator: invalid validate tag on field " + goName + ": unknown field " + strconv.Quote(ref))
+		}
+	}
+}
+
+func tagRules(f *SchemaField, goName string, tag string, known func(name string) bool) *SchemaField {
+	f, err := parseRules(f, tag, known)
+	if err != nil {
+		panic("validator: invalid validate tag on field " + goName + ": " + strings.TrimPrefix(err.Error(), "validator: "))
+	}
+	return f
+}
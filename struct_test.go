@@ -0,0 +1,90 @@
+package validator
+
+import "testing"
+
+type signupForm struct {
+	Email    string `json:"email" validate:"email,max=30"`
+	Username string `json:"username,omitempty" validate:"min=5,max=25"`
+	Website  string `json:"website" validate:"omitempty,url"`
+	Age      int    `validate:"min=18"`
+	Nickname string
+}
+
+func TestValidateStruct(t *testing.T) {
+	form := signupForm{
+		Email:    "test@example.com",
+		Username: "ctrix_user",
+		Age:      21,
+	}
+	if errs := ValidateStruct(form); errs != nil {
+		t.Errorf("ValidateStruct() should not return an error for valid input, got %v", errs)
+	}
+	if errs := ValidateStruct(&form); errs != nil {
+		t.Errorf("ValidateStruct() should accept a pointer to struct, got %v", errs)
+	}
+
+	form = signupForm{
+		Email:    "invalid-email",
+		Username: "ctrix",
+		Website:  "invalid-url",
+		Age:      12,
+	}
+	errs := ValidateStruct(form)
+	if len(errs) != 3 {
+		t.Fatalf("ValidateStruct() should return 3 errors, got %v", errs)
+	}
+	for i, field := range []string{"email", "website", "Age"} {
+		if errs[i].Field != field {
+			t.Errorf("ValidateStruct() error %d should be for field %q, got %q", i, field, errs[i].Field)
+		}
+	}
+}
+
+func TestValidateStructEmbedded(t *testing.T) {
+	type base struct {
+		ID string `json:"id" validate:"alphanumeric"`
+	}
+	type withBase struct {
+		base
+		Phone string `json:"phone" validate:"phone"`
+	}
+
+	errs := ValidateStruct(withBase{base: base{ID: "a-b"}, Phone: "+1234567890"})
+	if len(errs) != 1 || errs[0].Field != "id" {
+		t.Errorf("ValidateStruct() should validate embedded struct fields, got %v", errs)
+	}
+}
+
+func TestValidateStructPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"not a struct", "test"},
+		{"nil pointer", (*signupForm)(nil)},
+		{"unknown rule", struct {
+			Name string `validate:"unknown"`
+		}{"test"}},
+		{"bad parameter", struct {
+			Name string `validate:"min=five"`
+		}{"test"}},
+		{"unknown field", struct {
+			Password string `json:"password"`
+			Confirm  string `json:"confirm" validate:"eq_field=passwrod"`
+		}{"secret", "secret"}},
+		{"unknown conditional field", struct {
+			Street string `json:"street" validate:"required_with=cty"`
+		}{"Main St"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ValidateStruct() should panic for %s", tt.name)
+				}
+			}()
+			ValidateStruct(tt.data)
+		})
+	}
+}
@@ -0,0 +1,776 @@
+package validator
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Integer is satisfied by the integer types, including named ones
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is satisfied by the floating-point types, including named ones
+type Float interface {
+	~float32 | ~float64
+}
+
+/*
+typedField binds a typed field to the field of the collector it was declared as
+*/
+type typedField struct {
+	v  *Validator
+	id int
+}
+
+func bindField(v *Validator) typedField {
+	return typedField{v: v, id: v.fieldID}
+}
+
+// field returns the collector, panicking when it has moved on to another field,
+// as the rule would otherwise be applied to the wrong field
+func (f typedField) field() *Validator {
+	if f.v.fieldID != f.id {
+		panic("validator: rule applied to a typed field after the next field was declared on its collector")
+	}
+	return f.v
+}
+
+/*
+StringField validates a string field, only string rules are available on it
+
+Rules must be applied before the next field is declared on the collector, a rule applied to
+an earlier field panics.
+*/
+type StringField struct {
+	typedField
+}
+
+/*
+This function starts a typed validation with a string field
+
+- name: name of the field
+
+- s: data to be validated
+
+returns: *StringField
+
+Example:
+
+	v := validator.String("email", data.Email).Email().Max(30).Validator()
+	validator.NumberOf(v, "age", data.Age).Min(18)
+	errs := v.GetError()
+*/
+func String(name string, s string) *StringField {
+	return &StringField{typedField: bindField(NewValidator(name, s))}
+}
+
+/*
+This function declares the next field of v as a string field, its errors are collected by v
+
+returns: *StringField
+*/
+func StringOf(v *Validator, name string, s string) *StringField {
+	v.NextField(name, s)
+	return &StringField{typedField: bindField(v)}
+}
+
+/*
+This function makes the field optional
+
+returns: *StringField
+*/
+func (f *StringField) NotRequired() *StringField {
+	f.field().NotRequired()
+	return f
+}
+
+/*
+This function makes the empty string count as missing
+
+returns: *StringField
+*/
+func (f *StringField) ZeroIsEmpty() *StringField {
+	f.field().ZeroIsEmpty()
+	return f
+}
+
+/*
+This function checks if the string has at least length characters
+
+returns: *StringField
+*/
+func (f *StringField) Min(length int) *StringField {
+	f.field().Min(length)
+	return f
+}
+
+/*
+This function checks if the string has at most length characters
+
+returns: *StringField
+*/
+func (f *StringField) Max(length int) *StringField {
+	f.field().Max(length)
+	return f
+}
+
+/*
+This function checks if the field is a valid email
+
+returns: *StringField
+*/
+func (f *StringField) Email() *StringField {
+	f.field().Email()
+	return f
+}
+
+/*
+This function checks if the field is a valid url, see Validator.Url()
+
+returns: *StringField
+*/
+func (f *StringField) Url(policies ...URLPolicy) *StringField {
+	f.field().Url(policies...)
+	return f
+}
+
+/*
+This function checks if the field is a url that is safe to request from the server, see Validator.SafeOutboundURL()
+
+returns: *StringField
+*/
+func (f *StringField) SafeOutboundURL(policies ...URLPolicy) *StringField {
+	f.field().SafeOutboundURL(policies...)
+	return f
+}
+
+/*
+This function checks if the field contains only alphabets
+
+returns: *StringField
+*/
+func (f *StringField) Alpha() *StringField {
+	f.field().Alpha()
+	return f
+}
+
+/*
+This function checks if the field contains only Numeric Values
+
+returns: *StringField
+*/
+func (f *StringField) Numeric() *StringField {
+	f.field().Numeric()
+	return f
+}
+
+/*
+This function checks if the field contains only AlphaNumeric Values
+
+returns: *StringField
+*/
+func (f *StringField) AlphaNumeric() *StringField {
+	f.field().AlphaNumeric()
+	return f
+}
+
+/*
+This function checks if the field contains a valid date, see Validator.Date()
+
+returns: *StringField
+*/
+func (f *StringField) Date(layouts ...string) *StringField {
+	f.field().Date(layouts...)
+	return f
+}
+
+/*
+This function checks if the field matches the pattern
+
+returns: *StringField
+*/
+func (f *StringField) Match(pattern *regexp.Regexp) *StringField {
+	f.field().Match(pattern)
+	return f
+}
+
+/*
+This function checks if the field is a valid phone number
+
+returns: *StringField
+*/
+func (f *StringField) PhoneNumber() *StringField {
+	f.field().PhoneNumber()
+	return f
+}
+
+/*
+This function checks if the field is a valid credit card number, see Validator.CreditCard()
+
+returns: *StringField
+*/
+func (f *StringField) CreditCard(brands ...CardBrand) *StringField {
+	f.field().CreditCard(brands...)
+	return f
+}
+
+/*
+This function checks if the field is a card security code, see Validator.CVV()
+
+returns: *StringField
+*/
+func (f *StringField) CVV(brand CardBrand) *StringField {
+	f.field().CVV(brand)
+	return f
+}
+
+/*
+This function checks if the field is a valid IPv4 or IPv6 address
+
+returns: *StringField
+*/
+func (f *StringField) IPAddress() *StringField {
+	f.field().IPAddress()
+	return f
+}
+
+/*
+This function checks if the field is a valid IPv4 address
+
+returns: *StringField
+*/
+func (f *StringField) IPv4() *StringField {
+	f.field().IPv4()
+	return f
+}
+
+/*
+This function checks if the field is a valid IPv6 address
+
+returns: *StringField
+*/
+func (f *StringField) IPv6() *StringField {
+	f.field().IPv6()
+	return f
+}
+
+/*
+This function checks if the field is a valid CIDR prefix
+
+returns: *StringField
+*/
+func (f *StringField) CIDR() *StringField {
+	f.field().CIDR()
+	return f
+}
+
+/*
+This function checks if the field is an ip address in one of the prefixes, see Validator.IPInRange()
+
+returns: *StringField
+*/
+func (f *StringField) IPInRange(prefixes ...netip.Prefix) *StringField {
+	f.field().IPInRange(prefixes...)
+	return f
+}
+
+/*
+This function checks if the field is a public ip address, see Validator.PublicIP()
+
+returns: *StringField
+*/
+func (f *StringField) PublicIP() *StringField {
+	f.field().PublicIP()
+	return f
+}
+
+/*
+This function checks if the field is a private ip address, see Validator.PrivateIP()
+
+returns: *StringField
+*/
+func (f *StringField) PrivateIP() *StringField {
+	f.field().PrivateIP()
+	return f
+}
+
+/*
+This function checks if the field is a loopback address
+
+returns: *StringField
+*/
+func (f *StringField) Loopback() *StringField {
+	f.field().Loopback()
+	return f
+}
+
+/*
+This function checks if the field contains any special character
+
+returns: *StringField
+*/
+func (f *StringField) HasSpecialChar() *StringField {
+	f.field().HasSpecialChar()
+	return f
+}
+
+/*
+This function returns the errors of the collector
+
+returns: ValidationErrors, nil when no rule failed
+*/
+func (f *StringField) GetError() ValidationErrors {
+	return f.v.GetError()
+}
+
+/*
+This function returns the collector of the field, to declare further fields on it
+
+returns: *Validator
+*/
+func (f *StringField) Validator() *Validator {
+	return f.v
+}
+
+/*
+NumberField validates an integer or floating-point field, its bounds have the type of the field
+*/
+type NumberField[T Integer | Float] struct {
+	typedField
+	value T
+}
+
+/*
+This function starts a typed validation with a number field
+
+- name: name of the field
+
+- n: data to be validated
+
+returns: *NumberField[T]
+
+Example:
+
+	errs := validator.Number("price", data.Price).Min(0.01).Max(999.99).GetError()
+*/
+func Number[T Integer | Float](name string, n T) *NumberField[T] {
+	return &NumberField[T]{typedField: bindField(NewValidator(name, n)), value: n}
+}
+
+/*
+This function declares the next field of v as a number field, its errors are collected by v
+
+returns: *NumberField[T]
+*/
+func NumberOf[T Integer | Float](v *Validator, name string, n T) *NumberField[T] {
+	v.NextField(name, n)
+	return &NumberField[T]{typedField: bindField(v), value: n}
+}
+
+/*
+This function makes the field optional
+
+returns: *NumberField[T]
+*/
+func (f *NumberField[T]) NotRequired() *NumberField[T] {
+	f.field().NotRequired()
+	return f
+}
+
+/*
+This function makes 0 count as missing
+
+returns: *NumberField[T]
+*/
+func (f *NumberField[T]) ZeroIsEmpty() *NumberField[T] {
+	f.field().ZeroIsEmpty()
+	return f
+}
+
+/*
+This function checks if the number is greater than or equal to min
+
+returns: *NumberField[T]
+*/
+func (f *NumberField[T]) Min(min T) *NumberField[T] {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	// NaN compares false with every bound, so it would pass both Min and Max
+	if isNaN(f.value) || f.value < min {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMin,
+			Message: "must be greater than or equal to " + fmt.Sprint(min),
+			Params:  map[string]interface{}{"min": min},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function checks if the number is less than or equal to max
+
+returns: *NumberField[T]
+*/
+func (f *NumberField[T]) Max(max T) *NumberField[T] {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	if isNaN(f.value) || f.value > max {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMax,
+			Message: "must be less than or equal to " + fmt.Sprint(max),
+			Params:  map[string]interface{}{"max": max},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function returns the errors of the collector
+
+returns: ValidationErrors, nil when no rule failed
+*/
+func (f *NumberField[T]) GetError() ValidationErrors {
+	return f.v.GetError()
+}
+
+/*
+This function returns the collector of the field, to declare further fields on it
+
+returns: *Validator
+*/
+func (f *NumberField[T]) Validator() *Validator {
+	return f.v
+}
+
+// isNaN reports whether n is a floating-point NaN, it is false for every integer
+func isNaN[T Integer | Float](n T) bool {
+	return n != n
+}
+
+/*
+SliceField validates a slice field and its elements
+*/
+type SliceField[E any] struct {
+	typedField
+	value []E
+}
+
+/*
+This function starts a typed validation with a slice field
+
+- name: name of the field
+
+- s: data to be validated
+
+returns: *SliceField[E]
+*/
+func Slice[E any](name string, s []E) *SliceField[E] {
+	return &SliceField[E]{typedField: bindField(NewValidator(name, s)), value: s}
+}
+
+/*
+This function declares the next field of v as a slice field, its errors are collected by v
+
+returns: *SliceField[E]
+*/
+func SliceOf[E any](v *Validator, name string, s []E) *SliceField[E] {
+	v.NextField(name, s)
+	return &SliceField[E]{typedField: bindField(v), value: s}
+}
+
+/*
+This function makes the field optional
+
+returns: *SliceField[E]
+*/
+func (f *SliceField[E]) NotRequired() *SliceField[E] {
+	f.field().NotRequired()
+	return f
+}
+
+/*
+This function makes an empty slice count as missing
+
+returns: *SliceField[E]
+*/
+func (f *SliceField[E]) ZeroIsEmpty() *SliceField[E] {
+	f.field().ZeroIsEmpty()
+	return f
+}
+
+/*
+This function checks if the slice has at least length elements
+
+returns: *SliceField[E]
+*/
+func (f *SliceField[E]) Min(length int) *SliceField[E] {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	if len(f.value) < length {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMin,
+			Message: "must contain at least " + itemCount(length),
+			Params:  map[string]interface{}{"min": length},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function checks if the slice has at most length elements
+
+returns: *SliceField[E]
+*/
+func (f *SliceField[E]) Max(length int) *SliceField[E] {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	if len(f.value) > length {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMax,
+			Message: "must contain at most " + itemCount(length),
+			Params:  map[string]interface{}{"max": length},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function validates every element of the slice, see Validator.Each()
+
+- fn: validates elem, errors are reported as "field[i]"
+
+returns: *SliceField[E]
+
+Example:
+
+	validator.Slice("items", order.Items).Min(1).Each(func(v *validator.Validator, item Item) {
+	    validator.StringOf(v, "sku", item.SKU).AlphaNumeric()
+	    validator.NumberOf(v, "quantity", item.Quantity).Min(1)
+	})
+*/
+func (f *SliceField[E]) Each(fn func(v *Validator, elem E)) *SliceField[E] {
+	i := 0
+	f.field().Each(func(v *Validator) {
+		fn(v, f.value[i])
+		i++
+	})
+	return f
+}
+
+/*
+This function returns the errors of the collector
+
+returns: ValidationErrors, nil when no rule failed
+*/
+func (f *SliceField[E]) GetError() ValidationErrors {
+	return f.v.GetError()
+}
+
+/*
+This function returns the collector of the field, to declare further fields on it
+
+returns: *Validator
+*/
+func (f *SliceField[E]) Validator() *Validator {
+	return f.v
+}
+
+func itemCount(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return strconv.Itoa(n) + " items"
+}
+
+/*
+TimeField validates a time.Time field
+*/
+type TimeField struct {
+	typedField
+	value time.Time
+}
+
+/*
+This function starts a typed validation with a time field
+
+- name: name of the field
+
+- t: data to be validated
+
+returns: *TimeField
+*/
+func Time(name string, t time.Time) *TimeField {
+	return &TimeField{typedField: bindField(NewValidator(name, t)), value: t}
+}
+
+/*
+This function declares the next field of v as a time field, its errors are collected by v
+
+returns: *TimeField
+*/
+func TimeOf(v *Validator, name string, t time.Time) *TimeField {
+	v.NextField(name, t)
+	return &TimeField{typedField: bindField(v), value: t}
+}
+
+/*
+This function makes the field optional
+
+returns: *TimeField
+*/
+func (f *TimeField) NotRequired() *TimeField {
+	f.field().NotRequired()
+	return f
+}
+
+/*
+This function makes the zero time count as missing
+
+returns: *TimeField
+*/
+func (f *TimeField) ZeroIsEmpty() *TimeField {
+	f.field().ZeroIsEmpty()
+	return f
+}
+
+/*
+This function checks if the time is not before min
+
+returns: *TimeField
+*/
+func (f *TimeField) Min(min time.Time) *TimeField {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	if f.value.Before(min) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMin,
+			Message: "must not be before " + min.Format(time.RFC3339),
+			Params:  map[string]interface{}{"min": min},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function checks if the time is not after max
+
+returns: *TimeField
+*/
+func (f *TimeField) Max(max time.Time) *TimeField {
+	v := f.field()
+	if v.commonReturnCase() {
+		return f
+	}
+	if f.value.After(max) {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMax,
+			Message: "must not be after " + max.Format(time.RFC3339),
+			Params:  map[string]interface{}{"max": max},
+			Value:   f.value,
+		})
+	}
+	return f
+}
+
+/*
+This function checks if the time is strictly before t
+
+returns: *TimeField
+*/
+func (f *TimeField) Before(t time.Time) *TimeField {
+	f.field().Before(t)
+	return f
+}
+
+/*
+This function checks if the time is strictly after t
+
+returns: *TimeField
+*/
+func (f *TimeField) After(t time.Time) *TimeField {
+	f.field().After(t)
+	return f
+}
+
+/*
+This function checks if the time is between start and end, both included
+
+returns: *TimeField
+*/
+func (f *TimeField) Between(start time.Time, end time.Time) *TimeField {
+	f.field().Between(start, end)
+	return f
+}
+
+/*
+This function checks if the time is in the future, see Validator.Clock()
+
+returns: *TimeField
+*/
+func (f *TimeField) Future() *TimeField {
+	f.field().Future()
+	return f
+}
+
+/*
+This function checks if the time is in the past, see Validator.Clock()
+
+returns: *TimeField
+*/
+func (f *TimeField) Past() *TimeField {
+	f.field().Past()
+	return f
+}
+
+/*
+This function checks if the time is in the past, at most d ago, see Validator.Clock()
+
+returns: *TimeField
+*/
+func (f *TimeField) WithinLast(d time.Duration) *TimeField {
+	f.field().WithinLast(d)
+	return f
+}
+
+/*
+This function returns the errors of the collector
+
+returns: ValidationErrors, nil when no rule failed
+*/
+func (f *TimeField) GetError() ValidationErrors {
+	return f.v.GetError()
+}
+
+/*
+This function returns the collector of the field, to declare further fields on it
+
+returns: *Validator
+*/
+func (f *TimeField) Validator() *Validator {
+	return f.v
+}
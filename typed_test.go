@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStringField(t *testing.T) {
+	errs := String("email", "invalid-email").Email().Max(30).GetError()
+	if len(errs) != 1 || errs[0].Code != CodeEmail {
+		t.Errorf("String() errors = %v", errs)
+	}
+	if errs := String("nickname", "").NotRequired().ZeroIsEmpty().Min(3).GetError(); errs != nil {
+		t.Errorf("String() optional empty field = %v", errs)
+	}
+}
+
+func TestNumberField(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     ValidationErrors
+		expected bool
+	}{
+		{"int in range", Number("age", 30).Min(18).Max(99).GetError(), true},
+		{"int below min", Number("age", 17).Min(18).GetError(), false},
+		{"float above max", Number("price", 10.5).Max(10.49).GetError(), false},
+		{"float fraction", Number("price", 0.01).Min(0.01).GetError(), true},
+		{"uint64 beyond int", Number("id", uint64(math.MaxUint64)).Min(uint64(math.MaxInt64) + 1).GetError(), true},
+		{"uint64 above max", Number("id", uint64(math.MaxUint64)).Max(math.MaxUint64 - 1).GetError(), false},
+		{"zero quantity", Number("quantity", 0).Min(0).GetError(), true},
+		{"zero is empty", Number("quantity", 0).ZeroIsEmpty().Min(0).GetError(), false},
+		{"NaN below min", Number("price", math.NaN()).Min(0).GetError(), false},
+		{"NaN above max", Number("price", float32(math.NaN())).Max(100).GetError(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.errs == nil) != tt.expected {
+				t.Errorf("Number() for %s = %v; want %v", tt.name, tt.errs, tt.expected)
+			}
+		})
+	}
+
+	errs := Number("price", float32(1.5)).Min(2).GetError()
+	if errs[0].Message != "must be greater than or equal to 2" || errs[0].Params["min"] != float32(2) {
+		t.Errorf("Number() error = %+v", errs[0])
+	}
+}
+
+func TestSliceField(t *testing.T) {
+	type item struct {
+		SKU      string
+		Quantity int
+	}
+	items := []item{{"abc1", 1}, {"ab-2", 0}}
+
+	errs := Slice("items", items).Min(1).Max(5).Each(func(v *Validator, item item) {
+		StringOf(v, "sku", item.SKU).AlphaNumeric()
+		NumberOf(v, "quantity", item.Quantity).Min(1)
+	}).GetError()
+	if len(errs) != 2 || errs[0].Field != "items[1].sku" || errs[1].Field != "items[1].quantity" {
+		t.Errorf("Slice().Each() errors = %v", errs)
+	}
+
+	errs = Slice("tags", []string{"a", "b"}).Max(1).GetError()
+	if len(errs) != 1 || errs[0].Message != "must contain at most 1 item" {
+		t.Errorf("Slice().Max() errors = %v", errs)
+	}
+	errs = Slice("tags", []string{}).Min(2).GetError().Translate(LocaleTranslator("de"))
+	if len(errs) != 1 || errs[0].Message != "muss mindestens 2 Einträge enthalten" {
+		t.Errorf("Slice().Min() localized errors = %v", errs)
+	}
+}
+
+func TestTimeField(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	if errs := Time("date", start.AddDate(0, 6, 0)).Min(start).Max(end).GetError(); errs != nil {
+		t.Errorf("Time() in range = %v", errs)
+	}
+	errs := Time("date", start.AddDate(0, 0, -1)).Min(start).GetError()
+	if len(errs) != 1 || errs[0].Message != "must not be before 2024-01-01T00:00:00Z" {
+		t.Errorf("Time().Min() errors = %v", errs)
+	}
+	if errs := Time("date", time.Time{}).NotRequired().ZeroIsEmpty().Min(start).GetError(); errs != nil {
+		t.Errorf("Time() zero optional field = %v", errs)
+	}
+}
+
+func TestTypedSharedCollector(t *testing.T) {
+	v := String("email", "test@example.com").Email().Validator()
+	NumberOf(v, "age", uint8(16)).Min(18)
+	v.NextField("username", "ctrix").Min(5)
+	TimeOf(v, "birthday", time.Time{}).ZeroIsEmpty().Max(time.Now())
+
+	errs := v.GetError()
+	if len(errs) != 2 || errs[0].Field != "age" || errs[1].Field != "birthday" || errs[1].Code != CodeRequired {
+		t.Errorf("typed fields should share the collector, got %v", errs)
+	}
+}
+
+func TestTypedStaleField(t *testing.T) {
+	email := String("email", "not an email")
+	NumberOf(email.Validator(), "age", 30).Min(18)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("a rule on a field the collector has moved past should panic")
+		}
+	}()
+	email.Email()
+}
+
+func TestTypedFieldAfterEach(t *testing.T) {
+	items := Slice("items", []string{"a", "b"}).Each(func(v *Validator, item string) {
+		StringOf(v, "sku", item).Min(2)
+	})
+	errs := items.Min(3).GetError()
+	if len(errs) != 3 || errs[0].Field != "items[0].sku" || errs[2].Field != "items" {
+		t.Errorf("the slice field should stay current after Each, got %v", errs)
+	}
+}
@@ -1,45 +1,107 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
 	"strconv"
+	"time"
 )
 
-type validationError struct {
-	Field   string
-	Message string
-}
+// Built-in patterns are compiled once, rules must never call regexp.MustCompile per invocation
+var (
+	specialCharRegex = regexp.MustCompile(`[^a-zA-Z0-9\s]+`)
+	// Email pattern compliant with most RFCs while still using Go's RE2 engine
+	emailRegex        = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+	alphaRegex        = regexp.MustCompile(`^[a-zA-Z]+$`)
+	numericRegex      = regexp.MustCompile(`^[0-9]+$`)
+	alphaNumericRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	phoneRegex        = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+)
+
+/*
+Validator validates a sequence of fields, it is returned by NewValidator and passed to the callbacks of
+Nested(), Each() and When():
 
-type validatorApp struct {
+	vApp.NextField("items", order.Items).Each(func(v *validator.Validator) {
+	    v.NextField("sku", v.Value().(Item).SKU).AlphaNumeric()
+	})
+*/
+type Validator struct {
 	fieldName     string
 	data          interface{}
 	requiredField bool
-	errors        []validationError
+	errors        []ValidationError
 	foundErr      bool
+	translator    Translator
+
+	// scopePath is the path of the enclosing Nested/Each scope, fieldPath the path of the current field.
+	// Both are empty for top level fields, whose path is just fieldName.
+	scopePath []pathSegment
+	fieldPath []pathSegment
+
+	// values of the fields declared before the current one, for cross-field rules
+	values []namedValue
+
+	// fieldID identifies the current field among all fields declared so far, see typedField
+	fieldID   int
+	lastField int
+	// lookup resolves sibling fields of the map or struct a Schema is validating
+	lookup fieldLookup
+
+	// CustomCtx() rules waiting for ValidateCtx(), grouped by field
+	async   []asyncField
+	workers int
+
+	// strict reports rules applied to values of a type they cannot handle
+	strict bool
+
+	// zeroIsEmpty makes the zero value of the current field count as missing
+	zeroIsEmpty bool
+	// rules registered on the schemas enclosing the current field, innermost last
+	rules []map[string]RuleFunc
+
+	// presence lists the keys of a decoded JSON payload, fields missing from it are absent
+	presence Presence
+
+	// allErrors runs every rule of a field instead of stopping at its first error
+	allErrors bool
+	// maxErrors stops the validator once it collected that many errors, 0 for no limit
+	maxErrors int
+
+	// now is the clock of the date range rules, time.Now when nil
+	now func() time.Time
+	// dateLayouts are the layouts of the Date() rule of the current field
+	dateLayouts []string
+
+	// resolver looks up the hosts of SafeOutboundURL(), net.DefaultResolver when nil
+	resolver Resolver
 }
 
 /*
 This function checks if the field contains any special character
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) HasSpecialChar() *validatorApp {
+func (v *Validator) HasSpecialChar() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			specialCharRegex := regexp.MustCompile(`[^a-zA-Z0-9\s]+`)
-			if specialCharRegex.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if specialCharRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeHasSpecialChar,
 			Message: "must contain at least one special character",
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -47,26 +109,27 @@ func (v *validatorApp) HasSpecialChar() *validatorApp {
 /*
 This function checks if the field is a valid email
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Email() *validatorApp {
+func (v *Validator) Email() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			str := v.data.(string)
-			// Email pattern compliant with most RFCs while still using Go's RE2 engine
-			emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
-			if emailRegex.MatchString(str) {
+		if len(data) > 0 {
+			if emailRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeEmail,
 			Message: "must be a valid email",
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -74,120 +137,23 @@ func (v *validatorApp) Email() *validatorApp {
 /*
 This function checks if the field has minimum length
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Min(length int) *validatorApp {
-
+func (v *Validator) Min(length int) *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	errMessage := "must be greater than or equal to " + strconv.Itoa(length)
-
-	switch v.data.(type) {
-	case string:
-		if len(v.data.(string)) < length {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-		}
-	case int:
-		if v.data.(int) < length {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case int16:
-		if v.data.(int16) < int16(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case int32:
-		if v.data.(int32) < int32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case int64:
-		if v.data.(int64) < int64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case int8:
-		if v.data.(int8) < int8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case uint:
-		if v.data.(uint8) < uint8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case uint8:
-		if v.data.(uint8) < uint8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case uint16:
-		if v.data.(uint16) < uint16(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case uint32:
-		if v.data.(uint32) < uint32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-		}
-	case uint64:
-		if v.data.(uint64) < uint64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case float32:
-		if v.data.(float32) < float32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-
-	case float64:
-		if v.data.(float64) < float64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-		}
-
+	if cmp, ok := compareLength(v.data, length); !ok {
+		v.typeMismatch("string or number")
+	} else if cmp < 0 {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMin,
+			Message: "must be greater than or equal to " + strconv.Itoa(length),
+			Params:  map[string]interface{}{"min": length},
+			Value:   v.data,
+		})
 	}
 	return v
 }
@@ -195,177 +161,119 @@ func (v *validatorApp) Min(length int) *validatorApp {
 /*
 This function checks if the field has maximum length
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Max(length int) *validatorApp {
+func (v *Validator) Max(length int) *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	errMessage := "must be less than or equal to " + strconv.Itoa(length)
+	if cmp, ok := compareLength(v.data, length); !ok {
+		v.typeMismatch("string or number")
+	} else if cmp > 0 {
+		v.appendError(ValidationError{
+			Field:   v.fieldName,
+			Code:    CodeMax,
+			Message: "must be less than or equal to " + strconv.Itoa(length),
+			Params:  map[string]interface{}{"max": length},
+			Value:   v.data,
+		})
+	}
+	return v
+}
 
-	switch v.data.(type) {
+// compareLength compares the length of a string or the value of a number against length,
+// returning -1, 0 or +1. ok is false for types Min and Max do not handle.
+func compareLength(data interface{}, length int) (cmp int, ok bool) {
+	switch data := data.(type) {
 	case string:
-		if len(v.data.(string)) > length {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareInt64(int64(len(data)), int64(length)), true
 	case int:
-		if v.data.(int) > length {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareInt64(int64(data), int64(length)), true
+	case int8:
+		return compareInt64(int64(data), int64(length)), true
 	case int16:
-		if v.data.(int16) > int16(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareInt64(int64(data), int64(length)), true
 	case int32:
-		if v.data.(int32) > int32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareInt64(int64(data), int64(length)), true
 	case int64:
-		if v.data.(int64) > int64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-	case int8:
-		if v.data.(int8) > int8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareInt64(data, int64(length)), true
 	case uint:
-		if v.data.(uint8) > uint8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareUint64(uint64(data), length), true
 	case uint8:
-		if v.data.(uint8) > uint8(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareUint64(uint64(data), length), true
 	case uint16:
-		if v.data.(uint16) > uint16(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareUint64(uint64(data), length), true
 	case uint32:
-		if v.data.(uint32) > uint32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-		}
+		return compareUint64(uint64(data), length), true
 	case uint64:
-		if v.data.(uint64) > uint64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
+		return compareUint64(data, length), true
 	case float32:
-		if v.data.(float32) > float32(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-
-		}
-
+		return compareFloat64(float64(data), float64(length)), true
 	case float64:
-		if v.data.(float64) > float64(length) {
-			v.appendError(validationError{
-				Field:   v.fieldName,
-				Message: errMessage,
-			})
-		}
+		return compareFloat64(data, float64(length)), true
 	}
-	return v
+	return 0, false
 }
 
-/*
-This function checks if the field is a valid url
-
-returns: *validatorApp
-*/
-func (v *validatorApp) Url() *validatorApp {
-	if v.commonReturnCase() {
-		return v
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	}
+	return 0
+}
 
-	switch v.data.(type) {
-	case string:
-		if len(v.data.(string)) > 0 {
-			urlRegex := regexp.MustCompile(`^(https?|ftp):\/\/` + // protocol
-				`(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\-]*[a-zA-Z0-9])\.)*` + // subdomains
-				`([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\-]*[a-zA-Z0-9])` + // domain name
-				`(:\d+)?` + // port
-				`(\/[-a-zA-Z0-9_%\.~#?&=]*)*$`) // path, query params, fragment
-
-			if urlRegex.MatchString(v.data.(string)) {
-				return v
-			}
-		}
-		v.appendError(validationError{
-			Field:   v.fieldName,
-			Message: "must be a valid url",
-		})
+func compareUint64(a uint64, b int) int {
+	if b < 0 {
+		return 1
+	}
+	switch {
+	case a < uint64(b):
+		return -1
+	case a > uint64(b):
+		return 1
+	}
+	return 0
+}
 
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	}
-	return v
+	return 0
 }
 
 /*
 This function checks if the field contains only alphabets
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Alpha() *validatorApp {
+func (v *Validator) Alpha() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			alphaRegex := regexp.MustCompile(`^[a-zA-Z]+$`)
-			if alphaRegex.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if alphaRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeAlpha,
 			Message: "must contain only alphabets",
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -373,25 +281,28 @@ func (v *validatorApp) Alpha() *validatorApp {
 /*
 This function checks if the field contains only Numeric Values
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Numeric() *validatorApp {
+func (v *Validator) Numeric() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			numericRegex := regexp.MustCompile(`^[0-9]+$`)
-			if numericRegex.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if numericRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeNumeric,
 			Message: "must contain only numbers",
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -399,51 +310,28 @@ func (v *validatorApp) Numeric() *validatorApp {
 /*
 This function checks if the field contains only AlphaNumeric Values
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) AlphaNumeric() *validatorApp {
+func (v *Validator) AlphaNumeric() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			alphaNumericRegex := regexp.MustCompile(`^[a-zA-Z0-9]+$`)
-			if alphaNumericRegex.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if alphaNumericRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeAlphaNumeric,
 			Message: "must contain only alphabets and numbers",
+			Value:   v.data,
 		})
-	}
-	return v
-}
-
-/*
-This function checks if the field contains Valid Date
-
-returns: *validatorApp
-*/
-func (v *validatorApp) Date() *validatorApp {
-	if v.commonReturnCase() {
-		return v
-	}
-
-	switch v.data.(type) {
-	case string:
-		if len(v.data.(string)) > 0 {
-			dateRegex := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
-			if dateRegex.MatchString(v.data.(string)) {
-				return v
-			}
-		}
-		v.appendError(validationError{
-			Field:   v.fieldName,
-			Message: "must be a valid date",
-		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -451,24 +339,29 @@ func (v *validatorApp) Date() *validatorApp {
 /*
 This function checks if the field matches the pattern
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) Match(pattern *regexp.Regexp) *validatorApp {
+func (v *Validator) Match(pattern *regexp.Regexp) *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
 
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			if pattern.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if pattern.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeMatch,
 			Message: "must match the pattern",
+			Params:  map[string]interface{}{"pattern": pattern.String()},
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
@@ -479,7 +372,7 @@ This function applies validations conditionally if the provided condition is tru
 - condition: A function that evaluates to a boolean
 - validations: A function that applies validations to the validator
 
-returns: *validatorApp
+returns: *Validator
 
 Example:
 
@@ -488,7 +381,7 @@ Example:
 	        func() bool {
 	            return userType == "admin"
 	        },
-	        func(v *validatorApp) *validatorApp {
+	        func(v *Validator) *Validator {
 	            return v.Min(12).HasSpecialChar()
 	        },
 	    ).
@@ -496,112 +389,93 @@ Example:
 	        func() bool {
 	            return userType == "regular"
 	        },
-	        func(v *validatorApp) *validatorApp {
+	        func(v *Validator) *Validator {
 	            return v.Min(8)
 	        },
 	    )
 */
-// func (v *validatorApp) When(condition func() bool, validations func(*validatorApp) *validatorApp) *validatorApp {
-// 	// Skip this if we've already found errors or it's an optional empty field
-// 	if v.commonReturnCase() {
-// 		return v
-// 	}
-
-// 	// If the condition is true, run the validations
-// 	if condition() {
-// 		return validations(v)
-// 	}
-
-// 	// Otherwise, just return the validator as is
-// 	return v
-// }
-
-/*
-This function checks if the field is a valid phone number
-
-returns: *validatorApp
-*/
-func (v *validatorApp) PhoneNumber() *validatorApp {
-	if v.commonReturnCase() {
+func (v *Validator) When(condition func() bool, validations func(*Validator) *Validator) *Validator {
+	// Skip this if we've already found an error for this field. Nullish data is left to the
+	// validations, so that NotRequired() called inside them is honoured and a false
+	// condition never reports a required error.
+	if v.fieldFailed() {
 		return v
 	}
-	switch v.data.(type) {
-	case string:
-		if len(v.data.(string)) > 0 {
-			phoneRegex := regexp.MustCompile(`^\+?[0-9]{10,15}$`)
-			if phoneRegex.MatchString(v.data.(string)) {
-				return v
-			}
-		}
-		v.appendError(validationError{
-			Field:   v.fieldName,
-			Message: "must be a valid phone number",
-		})
+
+	// If the condition is true, run the validations
+	if condition() {
+		return validations(v)
 	}
+
+	// Otherwise, just return the validator as is
 	return v
 }
 
 /*
-This function checks if the field is a valid credit card number
+This function applies validations conditionally if the provided condition is false, the opposite of When()
 
-returns: *validatorApp
+- condition: A function that evaluates to a boolean
+- validations: A function that applies validations to the validator
+
+returns: *Validator
 */
-func (v *validatorApp) CreditCard() *validatorApp {
-	if v.commonReturnCase() {
-		return v
-	}
-	switch v.data.(type) {
-	case string:
-		if len(v.data.(string)) > 0 {
-			creditCardRegex := regexp.MustCompile(`^(?:4[0-9]{12}(?:[0-9]{3})?|5[1-5][0-9]{14}|6(?:011|5[0-9][0-9])[0-9]{12}|3[47][0-9]{13}|3(?:0[0-5]|[68][0-9])[0-9]{11}|(?:2131|1800|35\d{3})\d{11})$`)
-			if creditCardRegex.MatchString(v.data.(string)) {
-				return v
-			}
-		}
-		v.appendError(validationError{
-			Field:   v.fieldName,
-			Message: "must be a valid credit card number",
-		})
-	}
-	return v
+func (v *Validator) Unless(condition func() bool, validations func(*Validator) *Validator) *Validator {
+	return v.When(func() bool { return !condition() }, validations)
 }
 
 /*
-this function checks if the field is a valid ip address
+This function checks if the field is a valid phone number
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) IPAddress() *validatorApp {
+func (v *Validator) PhoneNumber() *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
-	switch v.data.(type) {
+	switch data := v.data.(type) {
 	case string:
-		if len(v.data.(string)) > 0 {
-			ipAddressRegex := regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`)
-			if ipAddressRegex.MatchString(v.data.(string)) {
+		if len(data) > 0 {
+			if phoneRegex.MatchString(data) {
 				return v
 			}
 		}
-		v.appendError(validationError{
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
-			Message: "must be a valid ip address",
+			Code:    CodePhoneNumber,
+			Message: "must be a valid phone number",
+			Value:   v.data,
 		})
+	default:
+		v.typeMismatch("string")
 	}
 	return v
 }
 
-func (v *validatorApp) appendError(err validationError) {
-	v.errors = append(v.errors, err)
+func (v *Validator) appendError(err ValidationError) {
+	if err.Path == nil {
+		err.Path = v.errorPath()
+	}
+	v.recordError(err)
 	v.foundErr = true
 }
 
+// recordError adds an error without marking the current field as failed
+func (v *Validator) recordError(err ValidationError) {
+	if v.limitReached() {
+		return
+	}
+	if v.translator != nil {
+		err = translate(v.translator, err)
+	}
+	v.errors = append(v.errors, err)
+}
+
 /*
 This function makes the field optional
 
-returns: *validatorApp
+returns: *Validator
 */
-func (v *validatorApp) NotRequired() *validatorApp {
+func (v *Validator) NotRequired() *Validator {
 	v.requiredField = false
 	return v
 }
@@ -609,9 +483,14 @@ func (v *validatorApp) NotRequired() *validatorApp {
 /*
 This function returns the errors
 
-returns: *validatorApp
+returns: ValidationErrors, nil when no rule failed
+
+Note: Fields with CustomCtx() rules that ValidateCtx() has not run yet are reported with the unchecked code.
 */
-func (v *validatorApp) GetError() []validationError {
+func (v *Validator) GetError() ValidationErrors {
+	if len(v.async) > 0 {
+		return append(v.errors[:len(v.errors):len(v.errors)], v.uncheckedErrors()...)
+	}
 	if len(v.errors) == 0 {
 		return nil
 	}
@@ -625,9 +504,9 @@ Allow custom error messages
 
 - message: custom error message
 
-- returns: *validatorApp
+- returns: *Validator
 */
-func (v *validatorApp) ChangeErrorMessage(fieldName string, message string) *validatorApp {
+func (v *Validator) ChangeErrorMessage(fieldName string, message string) *Validator {
 	if len(v.errors) > 0 {
 		for index, err := range v.errors {
 			if err.Field == fieldName {
@@ -639,7 +518,7 @@ func (v *validatorApp) ChangeErrorMessage(fieldName string, message string) *val
 }
 
 /* Allow transformation during validation */
-func (v *validatorApp) Transform(fn func(interface{}) interface{}) *validatorApp {
+func (v *Validator) Transform(fn func(interface{}) interface{}) *Validator {
 	if v.commonReturnCase() {
 		return v
 	}
@@ -647,39 +526,42 @@ func (v *validatorApp) Transform(fn func(interface{}) interface{}) *validatorApp
 	return v
 }
 
-func (v *validatorApp) commonReturnCase() bool {
-	var dataNullish bool
-
-	switch v.data.(type) {
-	case string:
-		if v.data.(string) == "" {
-			dataNullish = true
-		}
-	case int:
-		if v.data.(int) == 0 {
-			dataNullish = true
-		}
-	case float64:
-		if v.data.(float64) == float64(0) {
-			dataNullish = true
-		}
+func (v *Validator) commonReturnCase() bool {
+	// the first error of a field ends its validation, including a required error, unless AllErrors() is set
+	if v.fieldFailed() {
+		return true
 	}
 
+	dataNullish := v.isEmpty()
+
 	if dataNullish && !v.requiredField {
 		return true
 	}
 	if dataNullish && v.requiredField {
-		v.appendError(validationError{
+		// with AllErrors() every rule lands here, a missing field is reported once
+		if v.foundErr {
+			return true
+		}
+		v.appendError(ValidationError{
 			Field:   v.fieldName,
+			Code:    CodeRequired,
 			Message: "Field is Required",
+			Value:   v.data,
 		})
 		return true
 	}
+	return false
+}
 
-	if v.foundErr {
+// isNullish reports whether data is absent, i.e. nil or a nil pointer
+func isNullish(data interface{}) bool {
+	if data == nil {
 		return true
 	}
-	return false
+	if reflect.TypeOf(data).Kind() != reflect.Ptr {
+		return false
+	}
+	return reflect.ValueOf(data).IsNil()
 }
 
 /*
@@ -687,14 +569,25 @@ func (v *validatorApp) commonReturnCase() bool {
     *
   - - data: data to be validated
     *
-  - - returns: validatorApp
-  - Note : By Default field is considered required, please use NotRequired() immeadiately after to make it optional
+  - - returns: Validator
+  - Note : By Default field is considered required, please use NotRequired() immeadiately after to make it optional.
+    nil and nil pointers are missing, other pointers are dereferenced, zero values are present unless ZeroIsEmpty() is used
 */
-func (v *validatorApp) NextField(fieldName string, data interface{}) *validatorApp {
-	v.fieldName = fieldName
-	v.data = data
+func (v *Validator) NextField(fieldName string, data interface{}) *Validator {
+	v.remember()
+	if len(v.scopePath) == 0 {
+		v.fieldName = fieldName
+		v.fieldPath = nil
+	} else {
+		v.setFieldPath(append(v.scopePath[:len(v.scopePath):len(v.scopePath)], pathSegment{key: fieldName, index: -1}))
+	}
+	v.data = deref(data)
 	v.requiredField = true
+	v.zeroIsEmpty = false
+	v.dateLayouts = nil
 	v.foundErr = false
+	v.lastField++
+	v.fieldID = v.lastField
 	return v
 }
 
@@ -703,15 +596,15 @@ func (v *validatorApp) NextField(fieldName string, data interface{}) *validatorA
 
 - data: data to be validated
 
-- returns: validatorApp
+- returns: Validator
 
-- Note: By Default field is considered required, please use NotRequired() immeadiately after to make it optional
+- Note: By Default field is considered required, please use NotRequired() immeadiately after to make it optional.
+nil and nil pointers are missing, other pointers are dereferenced, zero values are present unless ZeroIsEmpty() is used
 */
-func NewValidator(fieldName string, data interface{}) *validatorApp {
-	vApp := &validatorApp{
+func NewValidator(fieldName string, data interface{}) *Validator {
+	vApp := &Validator{
 		fieldName:     fieldName,
-		data:          data,
-		errors:        make([]validationError, 0),
+		data:          deref(data),
 		foundErr:      false,
 		requiredField: true,
 	}
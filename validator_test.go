@@ -308,9 +308,10 @@ func TestEdgeCases(t *testing.T) {
 		value    interface{}
 		expected bool
 	}{
+		{"nil", nil, false},
 		{"empty string", "", false},
-		{"zero int", 0, false},
-		{"zero float", 0.0, false},
+		{"zero int", 0, true},
+		{"zero float", 0.0, true},
 		{"special chars only", "@#$%", true},
 		{"special chars with number", "123@#$%", true},
 		{"whitespace only", "   ", false},
@@ -332,31 +333,31 @@ func TestCombinedValidations(t *testing.T) {
 	tests := []struct {
 		name        string
 		value       string
-		validations func(*validatorApp)
+		validations func(*Validator)
 		expected    bool
 	}{
 		{
 			"valid email with min length",
 			"test@example.com",
-			func(v *validatorApp) { v.Email().Min(10) },
+			func(v *Validator) { v.Email().Min(10) },
 			true,
 		},
 		{
 			"valid phone with special chars",
 			"+12345678900",
-			func(v *validatorApp) { v.PhoneNumber().HasSpecialChar() },
+			func(v *Validator) { v.PhoneNumber().HasSpecialChar() },
 			true,
 		},
 		{
 			"valid URL with alpha numeric",
 			"example123com",
-			func(v *validatorApp) { v.Url().AlphaNumeric() },
+			func(v *Validator) { v.Url().AlphaNumeric() },
 			false,
 		},
 		{
 			"invalid combination",
 			"test",
-			func(v *validatorApp) { v.Email().PhoneNumber() },
+			func(v *Validator) { v.Email().PhoneNumber() },
 			false,
 		},
 	}
@@ -428,25 +429,25 @@ func TestNotRequiredWithValidations(t *testing.T) {
 	tests := []struct {
 		name        string
 		value       interface{}
-		validations func(*validatorApp)
+		validations func(*Validator)
 		expected    bool
 	}{
 		{
 			"empty not required",
 			"",
-			func(v *validatorApp) { v.NotRequired().Email() },
+			func(v *Validator) { v.NotRequired().ZeroIsEmpty().Email() },
 			true,
 		},
 		{
 			"empty not required with multiple validations",
 			"",
-			func(v *validatorApp) { v.NotRequired().Email().Min(5) },
+			func(v *Validator) { v.NotRequired().ZeroIsEmpty().Email().Min(5) },
 			true,
 		},
 		{
 			"invalid not required",
 			"invalid",
-			func(v *validatorApp) { v.NotRequired().Email() },
+			func(v *Validator) { v.NotRequired().Email() },
 			false,
 		},
 	}
@@ -461,3 +462,105 @@ func TestNotRequiredWithValidations(t *testing.T) {
 		})
 	}
 }
+
+func TestMinMaxBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		min, max int
+		expected bool
+	}{
+		{"uint", uint(42), 40, 50, true},
+		{"uint below", uint(3), 40, 50, false},
+		{"int8 bound beyond range", int8(100), 0, 300, true},
+		{"uint with negative min", uint8(1), -5, 10, true},
+		{"float between", 10.5, 10, 11, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator("field", tt.value).Min(tt.min).Max(tt.max)
+			if (v.GetError() == nil) != tt.expected {
+				t.Errorf("Min/Max for %s = %v; want %v", tt.name, v.GetError() == nil, tt.expected)
+			}
+		})
+	}
+}
+
+// raceEnabled is set by race_test.go when the tests run with -race
+var raceEnabled bool
+
+func TestPassingChainAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not reliable under the race detector")
+	}
+	email, username, age := interface{}("test@example.com"), interface{}("ctrix123"), interface{}(42)
+	card, url, date := interface{}("4111 1111 1111 1111"), interface{}("https://example.com/path?q=1"), interface{}("2024-05-01")
+	v := NewValidator("email", email)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		v.NextField("email", email).Email().Min(5).Max(30)
+		v.NextField("username", username).Min(5).Max(25).AlphaNumeric()
+		v.NextField("age", age).Min(18).Max(99)
+		v.NextField("card", card).CreditCard(Visa, Mastercard)
+		v.NextField("url", url).Url()
+		v.NextField("date", date).Date()
+	})
+	if v.GetError() != nil {
+		t.Fatalf("passing validation chain reported errors: %v", v.GetError())
+	}
+	if allocs != 0 {
+		t.Errorf("passing validation chain allocated %v times per run; want 0", allocs)
+	}
+}
+
+func BenchmarkNewValidatorPassing(b *testing.B) {
+	email := interface{}("test@example.com")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewValidator("email", email).Email().Min(5).Max(30)
+	}
+}
+
+func BenchmarkNextFieldPassing(b *testing.B) {
+	email, url, phone, age := interface{}("test@example.com"), interface{}("https://example.com/path"), interface{}("+1234567890"), interface{}(42)
+	v := NewValidator("email", email)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.NextField("email", email).Email().Min(5).Max(30)
+		v.NextField("url", url).Url()
+		v.NextField("phone", phone).PhoneNumber()
+		v.NextField("age", age).Min(18).Max(99)
+	}
+}
+
+func BenchmarkStringRules(b *testing.B) {
+	rules := []struct {
+		name  string
+		value interface{}
+		rule  func(*Validator) *Validator
+	}{
+		{"Email", "test@example.com", (*Validator).Email},
+		{"Url", "https://example.com/path?query=1", func(v *Validator) *Validator { return v.Url() }},
+		{"Alpha", "ctrix", (*Validator).Alpha},
+		{"Numeric", "1234567890", (*Validator).Numeric},
+		{"AlphaNumeric", "ctrix123", (*Validator).AlphaNumeric},
+		{"Date", "2023-10-01", func(v *Validator) *Validator { return v.Date() }},
+		{"PhoneNumber", "+1234567890", (*Validator).PhoneNumber},
+		{"CreditCard", "4111111111111111", func(v *Validator) *Validator { return v.CreditCard() }},
+		{"IPAddress", "192.168.1.1", (*Validator).IPAddress},
+		{"HasSpecialChar", "test@123", (*Validator).HasSpecialChar},
+	}
+
+	for _, r := range rules {
+		b.Run(r.name, func(b *testing.B) {
+			v := NewValidator("field", r.value)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.rule(v.NextField("field", r.value))
+			}
+		})
+	}
+}